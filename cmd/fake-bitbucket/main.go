@@ -1,10 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/n8n/bitbucket-mcp/internal/middleware"
 )
@@ -33,16 +36,67 @@ func main() {
 		_, _ = w.Write([]byte(`{"values":[{"slug":"my-repo","name":"my-repo"}],"size":1,"isLastPage":true}`))
 	})
 
-	// Branches (for bitbucket_list_branches)
+	// Branches and file edits (for bitbucket_list_branches, bitbucket_edit_file and
+	// bitbucket_edit_files). Each edit advances the branch head by one commit.
+	var (
+		mu      sync.Mutex
+		commits int
+		heads   = map[string]string{"main": "0000000000000000000000000000000000000001"}
+	)
 	mux.HandleFunc("/rest/api/1.0/projects/FAKE/repos/my-repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		filter := r.URL.Query().Get("filterText")
+		values := []map[string]string{}
+		for name, head := range heads {
+			if strings.Contains(name, filter) {
+				values = append(values, map[string]string{"id": "refs/heads/" + name, "displayId": name, "latestCommit": head})
+			}
+		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"values":[{"id":"refs/heads/main","displayId":"main"}],"size":1,"isLastPage":true}`))
+		_ = json.NewEncoder(w).Encode(map[string]any{"values": values, "size": len(values), "isLastPage": true})
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/FAKE/repos/my-repo/browse/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"lines":[{"text":"fake content"}],"isLastPage":true}`))
+			return
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		branch := strings.TrimPrefix(r.FormValue("branch"), "refs/heads/")
+		parent, ok := heads[branch]
+		if !ok {
+			if parent, ok = heads[strings.TrimPrefix(r.FormValue("sourceBranch"), "refs/heads/")]; !ok {
+				http.Error(w, `{"errors":[{"message":"branch not found"}]}`, http.StatusNotFound)
+				return
+			}
+		}
+		if since := r.FormValue("sourceCommitId"); since != "" && since != parent {
+			http.Error(w, `{"errors":[{"message":"file was modified since sourceCommitId"}]}`, http.StatusConflict)
+			return
+		}
+		commits++
+		id := fmt.Sprintf("%040x", commits+1)
+		heads[branch] = id
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id": id, "displayId": id[:11], "message": r.FormValue("message"),
+			"parents": []map[string]string{{"id": parent, "displayId": parent[:11]}},
+		})
 	})
 
 	// Search (for bitbucket_search_code)
 	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"query":"test","entities":{"code":{"count":0,"limit":25}}}`))
+		_, _ = w.Write([]byte(`{"scope":{"type":"GLOBAL"},"code":{"category":"primary","isLastPage":true,"count":1,"start":0,` +
+			`"values":[{"repository":{"slug":"my-repo","name":"my-repo","project":{"key":"FAKE","name":"Fake Project"}},` +
+			`"file":"README.md","hitContexts":[[{"line":1,"text":"# <em>test</em>"}]],"pathMatches":[],"hitCount":1}]},` +
+			`"query":{"substituted":false}}`))
 	})
 
 	addr := fmt.Sprintf(":%d", *port)
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,6 +12,8 @@ import (
 	"syscall"
 	"time"
 
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/n8n/bitbucket-mcp/internal/auth"
 	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
 	"github.com/n8n/bitbucket-mcp/internal/config"
 	"github.com/n8n/bitbucket-mcp/internal/mcp"
@@ -17,6 +21,9 @@ import (
 )
 
 func main() {
+	transport := flag.String("transport", "http", "MCP transport: http (Streamable HTTP) or stdio")
+	flag.Parse()
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("config: %v", err)
@@ -25,9 +32,46 @@ func main() {
 	client := bitbucket.NewClient(cfg.BitbucketURL, cfg.ExtraHeaders, cfg.LogLevel)
 	srv := mcp.NewServer(client, cfg.DefaultProjectKey)
 
-	resourceMetadataURL := cfg.MCPPublicURL + "/.well-known/oauth-protected-resource" + cfg.MCPHTTPEndpoint
-	handler := middleware.LogRequests(cfg.LogLevel, log.Default())(
-		middleware.ProxyHeaders(cfg.ProxyHeaders)(mcp.AuthMiddleware(resourceMetadataURL)(srv.Handler())))
+	switch *transport {
+	case "http":
+		serveHTTP(cfg, client, srv)
+	case "stdio":
+		serveStdio(cfg, srv)
+	default:
+		log.Fatalf("unknown transport %q (use http or stdio)", *transport)
+	}
+}
+
+// serveStdio runs the MCP server over stdin/stdout for clients that spawn it as a
+// local process. Stdout carries the protocol; logs go to stderr.
+func serveStdio(cfg *config.Config, srv *mcp.Server) {
+	if cfg.Token == "" {
+		log.Fatalf("config: BITBUCKET_TOKEN or BITBUCKET_TOKEN_FILE is required with --transport=stdio")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := srv.RunStdio(ctx, cfg.Token); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatalf("server: %v", err)
+	}
+}
+
+func serveHTTP(cfg *config.Config, client *bitbucket.Client, srv *mcp.Server) {
+	// API key clients cannot use Bitbucket's OAuth server, so service mode does not advertise it.
+	oauthDiscovery := cfg.AuthMode != config.AuthModeService
+	resourceMetadataURL := ""
+	if oauthDiscovery {
+		resourceMetadataURL = cfg.MCPPublicURL + "/.well-known/oauth-protected-resource" + cfg.MCPHTTPEndpoint
+	}
+	authed := mcp.AuthMiddleware(resourceMetadataURL, tokenVerifier(cfg, client))(srv.Handler())
+	if cfg.BasicAuth {
+		userHeader, passwordHeader := "", ""
+		if len(cfg.BasicAuthHeaders) == 2 {
+			userHeader, passwordHeader = cfg.BasicAuthHeaders[0], cfg.BasicAuthHeaders[1]
+		}
+		authed = auth.BasicAuth(userHeader, passwordHeader)(authed)
+		log.Printf("accepting Basic auth credentials and forwarding them to Bitbucket")
+	}
+	handler := middleware.LogRequests(cfg.LogLevel, log.Default())(middleware.ProxyHeaders(cfg.ProxyHeaders)(authed))
 
 	mux := http.NewServeMux()
 	mux.Handle(cfg.MCPHTTPEndpoint, handler)
@@ -36,9 +80,21 @@ func main() {
 		_, _ = fmt.Fprint(w, `{"status":"healthy"}`)
 	})
 	// OAuth 2.0 Protected Resource Metadata (RFC 9728) for discovery by VS Code, Cursor, etc.
-	wellKnown := mcp.ProtectedResourceMetadataHandler(cfg.MCPPublicURL, cfg.MCPHTTPEndpoint, cfg.BitbucketURL)
-	mux.Handle("/.well-known/oauth-protected-resource", wellKnown)
-	mux.Handle("/.well-known/oauth-protected-resource"+cfg.MCPHTTPEndpoint, wellKnown)
+	if oauthDiscovery {
+		authorizationServer := cfg.BitbucketURL
+		if cfg.OAuthClientID != "" {
+			authorizationServer = cfg.MCPPublicURL
+			registerOAuth(mux, cfg)
+		}
+		wellKnown := mcp.ProtectedResourceMetadataHandler(cfg.MCPPublicURL, cfg.MCPHTTPEndpoint, authorizationServer)
+		mux.Handle("/.well-known/oauth-protected-resource", wellKnown)
+		mux.Handle("/.well-known/oauth-protected-resource"+cfg.MCPHTTPEndpoint, wellKnown)
+	}
+	// Bitbucket webhook deliveries are authenticated by their HMAC signature, not a bearer token.
+	if cfg.WebhookSecret != "" {
+		mux.Handle(cfg.WebhookEndpoint, middleware.LogRequests(cfg.LogLevel, log.Default())(srv.WebhookHandler(cfg.WebhookSecret)))
+		log.Printf("receiving Bitbucket webhooks on %s", cfg.WebhookEndpoint)
+	}
 
 	addr := fmt.Sprintf(":%d", cfg.MCPHTTPPort)
 	server := &http.Server{
@@ -63,3 +119,37 @@ func main() {
 		log.Fatalf("server: %v", err)
 	}
 }
+
+// registerOAuth serves authorization server metadata and client registration in front of
+// Bitbucket's OAuth 2.0 provider. Bitbucket access tokens are then sent as bearer tokens
+// like PATs, so the verifiers accept both.
+func registerOAuth(mux *http.ServeMux, cfg *config.Config) {
+	oauth := mcp.OAuthConfig{
+		PublicURL:    cfg.MCPPublicURL,
+		BitbucketURL: cfg.BitbucketURL,
+		ClientID:     cfg.OAuthClientID,
+		ClientSecret: cfg.OAuthClientSecret,
+		RedirectURL:  cfg.OAuthRedirectURL,
+		Headers:      cfg.ExtraHeaders,
+	}
+	mux.Handle(mcp.OAuthMetadataPath, mcp.AuthorizationServerMetadataHandler(oauth))
+	mux.Handle(mcp.OAuthRegisterPath, mcp.ClientRegistrationHandler(oauth))
+	// Not wrapped in LogRequests: debug logging would print authorization codes and refresh tokens.
+	if cfg.OAuthClientSecret != "" {
+		mux.Handle(mcp.OAuthTokenPath, mcp.TokenProxyHandler(oauth))
+	}
+	log.Printf("OAuth sign-in through Bitbucket application link %s", cfg.OAuthClientID)
+}
+
+// tokenVerifier selects how bearer tokens of HTTP clients are checked (MCP_AUTH_MODE).
+func tokenVerifier(cfg *config.Config, client *bitbucket.Client) sdkauth.TokenVerifier {
+	switch cfg.AuthMode {
+	case config.AuthModeService:
+		log.Printf("service account mode: %d API key(s), Bitbucket calls use the service token", len(cfg.APIKeys))
+		return auth.ServiceAccountVerifier(cfg.Token, cfg.APIKeys)
+	case config.AuthModeVerify:
+		log.Printf("verifying tokens with Bitbucket (cache %s, rejected %s)", cfg.AuthCacheTTL, cfg.AuthNegativeTTL)
+		return auth.BitbucketVerifier(client, cfg.AuthCacheTTL, cfg.AuthNegativeTTL)
+	}
+	return auth.PassthroughVerifier()
+}
@@ -2,10 +2,12 @@ module github.com/n8n/bitbucket-mcp
 
 go 1.26.0
 
-require github.com/modelcontextprotocol/go-sdk v1.4.0
+require (
+	github.com/go-resty/resty/v2 v2.17.2
+	github.com/modelcontextprotocol/go-sdk v1.4.0
+)
 
 require (
-	github.com/go-resty/resty/v2 v2.17.2 // indirect
 	github.com/google/jsonschema-go v0.4.2 // indirect
 	github.com/segmentio/asm v1.1.3 // indirect
 	github.com/segmentio/encoding v0.5.3 // indirect
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// ExtraBitbucketUser is the TokenInfo.Extra key holding the username to send with
+// HTTP Basic auth; the password or token is under ExtraBitbucketToken.
+const ExtraBitbucketUser = "bitbucketUser"
+
+type basicCredentialsKey struct{}
+
+type basicCredentials struct {
+	username string
+	password string
+}
+
+// BasicAuth returns middleware, placed in front of sdkauth.RequireBearerToken, that lets
+// clients send a Bitbucket username and password or token instead of a bearer token:
+// "Authorization: Basic", or the userHeader/passwordHeader pair when both are set.
+// sdkauth only understands bearer tokens, so the credentials are re-sent as one and the
+// verifiers of this package pick them up from the request context. 401 responses also
+// carry a Basic challenge next to the Bearer one.
+func BasicAuth(userHeader, passwordHeader string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w = basicChallengeWriter{w}
+			user, pass, ok := r.BasicAuth()
+			if !ok && userHeader != "" && r.Header.Get("Authorization") == "" {
+				user, pass = r.Header.Get(userHeader), r.Header.Get(passwordHeader)
+				ok = user != "" && pass != ""
+			}
+			if ok {
+				r = r.Clone(context.WithValue(r.Context(), basicCredentialsKey{}, basicCredentials{user, pass}))
+				r.Header.Set("Authorization", "Bearer "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// basicChallengeWriter adds a Basic WWW-Authenticate challenge to 401 responses.
+type basicChallengeWriter struct {
+	http.ResponseWriter
+}
+
+func (w basicChallengeWriter) WriteHeader(status int) {
+	if status == http.StatusUnauthorized {
+		w.Header().Add("WWW-Authenticate", `Basic realm="Bitbucket", charset="UTF-8"`)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush keeps streamed MCP responses working through the wrapper.
+func (w basicChallengeWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w basicChallengeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// BitbucketUsername returns the username to send with Basic auth for a verified request,
+// or "" when the token is a bearer token.
+func BitbucketUsername(info *sdkauth.TokenInfo) string {
+	if info == nil {
+		return ""
+	}
+	u, _ := info.Extra[ExtraBitbucketUser].(string)
+	return u
+}
+
+func basicFromRequest(req *http.Request) (basicCredentials, bool) {
+	if req == nil {
+		return basicCredentials{}, false
+	}
+	c, ok := req.Context().Value(basicCredentialsKey{}).(basicCredentials)
+	return c, ok
+}
+
+// basicTokenInfo is the TokenInfo for Basic credentials that were not checked with Bitbucket.
+// UserID is a hash of username and password, so a session stays bound to the exact
+// credentials that opened it rather than to a username anyone can claim.
+func basicTokenInfo(c basicCredentials) *sdkauth.TokenInfo {
+	sum := sha256.Sum256([]byte(c.username + ":" + c.password))
+	return &sdkauth.TokenInfo{
+		UserID:     "basic:" + hex.EncodeToString(sum[:]),
+		Expiration: time.Now().Add(24 * time.Hour),
+		Scopes:     []string{"REPO_READ", "REPO_WRITE", "PROJECT_READ"},
+		Extra:      map[string]any{ExtraBitbucketToken: c.password, ExtraBitbucketUser: c.username},
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func TestBasicAuth(t *testing.T) {
+	var got *sdkauth.TokenInfo
+	h := BasicAuth("X-Bitbucket-User", "X-Bitbucket-Token")(
+		sdkauth.RequireBearerToken(PassthroughVerifier(), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = sdkauth.TokenInfoFromContext(r.Context())
+		})))
+
+	tests := []struct {
+		name           string
+		header         func(*http.Request)
+		user, password string
+	}{
+		{"basic", func(r *http.Request) { r.SetBasicAuth("svc", "pw:1") }, "svc", "pw:1"},
+		{"header pair", func(r *http.Request) {
+			r.Header.Set("X-Bitbucket-User", "svc")
+			r.Header.Set("X-Bitbucket-Token", "tok")
+		}, "svc", "tok"},
+		{"bearer", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer pat")
+			r.Header.Set("X-Bitbucket-User", "ignored")
+			r.Header.Set("X-Bitbucket-Token", "ignored")
+		}, "", "pat"},
+	}
+	for _, tt := range tests {
+		got = nil
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		tt.header(req)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || got == nil {
+			t.Errorf("%s: status = %d", tt.name, rec.Code)
+			continue
+		}
+		if BitbucketUsername(got) != tt.user || BitbucketToken(got) != tt.password {
+			t.Errorf("%s: credentials = %q %q", tt.name, BitbucketUsername(got), BitbucketToken(got))
+		}
+		if tt.user != "" && (got.UserID == tt.user || !strings.HasPrefix(got.UserID, "basic:")) {
+			t.Errorf("%s: UserID = %q, want a credential hash", tt.name, got.UserID)
+		}
+	}
+
+	// Same username, different password: a different session identity.
+	ids := map[string]bool{}
+	for _, pass := range []string{"pw1", "pw2"} {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.SetBasicAuth("svc", pass)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		ids[got.UserID] = true
+	}
+	if len(ids) != 2 {
+		t.Errorf("UserID does not depend on the password: %v", ids)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("X-Bitbucket-User", "svc")
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("half a header pair: status = %d, want 401", rec.Code)
+	}
+	if c := rec.Header().Get("WWW-Authenticate"); !strings.HasPrefix(c, `Basic realm="Bitbucket"`) {
+		t.Errorf("WWW-Authenticate = %q", c)
+	}
+}
@@ -12,11 +12,15 @@ import (
 // PassthroughVerifier returns a TokenVerifier that accepts any non-empty token
 // and returns TokenInfo with UserID set to the raw token (for proxying to Bitbucket).
 // Expiration is set to 24h from now to satisfy auth.RequireBearerToken checks.
+// Credentials rewritten by BasicAuth are forwarded as Basic auth, with UserID a hash of them.
 func PassthroughVerifier() sdkauth.TokenVerifier {
-	return func(_ context.Context, token string, _ *http.Request) (*sdkauth.TokenInfo, error) {
+	return func(_ context.Context, token string, req *http.Request) (*sdkauth.TokenInfo, error) {
 		if token == "" {
 			return nil, fmt.Errorf("%w: empty token", sdkauth.ErrInvalidToken)
 		}
+		if c, ok := basicFromRequest(req); ok {
+			return basicTokenInfo(c), nil
+		}
 		return &sdkauth.TokenInfo{
 			UserID:     token,
 			Expiration: time.Now().Add(24 * time.Hour),
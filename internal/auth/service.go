@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// TokenInfo.Extra keys set by verifiers that do not forward the client's own token.
+const (
+	ExtraBitbucketToken = "bitbucketToken" // token to send to Bitbucket instead of UserID
+	ExtraClient         = "client"         // name of the authenticated API key
+)
+
+// BitbucketToken returns the token to forward to Bitbucket for a verified request:
+// the service account token when the verifier set one, otherwise the raw client token.
+func BitbucketToken(info *sdkauth.TokenInfo) string {
+	if info == nil {
+		return ""
+	}
+	if t, ok := info.Extra[ExtraBitbucketToken].(string); ok && t != "" {
+		return t
+	}
+	return info.UserID
+}
+
+type apiKey struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+// ServiceAccountVerifier returns a TokenVerifier for trusted deployments: clients
+// authenticate with one of the static API keys (name → key) and every Bitbucket call
+// uses serviceToken, so clients never hold a Bitbucket token themselves.
+// UserID is "apikey:<name>", which keeps MCP sessions bound to one client.
+func ServiceAccountVerifier(serviceToken string, keys map[string]string) sdkauth.TokenVerifier {
+	hashed := make([]apiKey, 0, len(keys))
+	for name, key := range keys {
+		hashed = append(hashed, apiKey{name: name, hash: sha256.Sum256([]byte(key))})
+	}
+	return func(_ context.Context, token string, _ *http.Request) (*sdkauth.TokenInfo, error) {
+		if token == "" {
+			return nil, fmt.Errorf("%w: empty token", sdkauth.ErrInvalidToken)
+		}
+		// Compare against every key so timing does not reveal which one matched.
+		sum := sha256.Sum256([]byte(token))
+		name := ""
+		for _, k := range hashed {
+			if subtle.ConstantTimeCompare(sum[:], k.hash[:]) == 1 {
+				name = k.name
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%w: unknown API key", sdkauth.ErrInvalidToken)
+		}
+		return &sdkauth.TokenInfo{
+			UserID:     "apikey:" + name,
+			Expiration: time.Now().Add(24 * time.Hour),
+			Scopes:     []string{"REPO_READ", "REPO_WRITE", "PROJECT_READ"},
+			Extra:      map[string]any{ExtraBitbucketToken: serviceToken, ExtraClient: name},
+		}, nil
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func TestServiceAccountVerifier(t *testing.T) {
+	verifier := ServiceAccountVerifier("service-pat", map[string]string{"n8n": "key-1", "ci": "key-2"})
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	info, err := verifier(context.Background(), "key-2", req)
+	if err != nil {
+		t.Fatalf("verifier: %v", err)
+	}
+	if info.UserID != "apikey:ci" || info.Extra[ExtraClient] != "ci" {
+		t.Errorf("info = %+v", info)
+	}
+	if got := BitbucketToken(info); got != "service-pat" {
+		t.Errorf("BitbucketToken = %q, want service-pat", got)
+	}
+	if info.Expiration.IsZero() || len(info.Scopes) == 0 {
+		t.Error("Expiration and Scopes should be set")
+	}
+
+	for _, token := range []string{"", "service-pat", "key-3"} {
+		if _, err := verifier(context.Background(), token, req); !errors.Is(err, sdkauth.ErrInvalidToken) {
+			t.Errorf("token %q: err = %v, want ErrInvalidToken", token, err)
+		}
+	}
+}
+
+func TestBitbucketToken(t *testing.T) {
+	if got := BitbucketToken(nil); got != "" {
+		t.Errorf("nil info = %q", got)
+	}
+	if got := BitbucketToken(&sdkauth.TokenInfo{UserID: "raw"}); got != "raw" {
+		t.Errorf("passthrough = %q, want raw", got)
+	}
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+// maxCachedTokens bounds the identity cache; expired entries are pruned first when it fills.
+const maxCachedTokens = 10000
+
+// ExtraDisplayName is the TokenInfo.Extra key holding the verified user's display name.
+const ExtraDisplayName = "displayName"
+
+type cachedIdentity struct {
+	user    *bitbucket.User // nil for a token Bitbucket rejected
+	expires time.Time
+}
+
+// tokenCache remembers which user a token belongs to, keyed by the token's SHA-256 so
+// raw tokens are not kept in memory longer than a request.
+type tokenCache struct {
+	client      *bitbucket.Client
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cachedIdentity
+}
+
+// BitbucketVerifier returns a TokenVerifier that checks each token with Bitbucket's
+// /users/current on first use and caches the answer: valid tokens for ttl, rejected ones
+// for negativeTTL. Rejected tokens fail with ErrInvalidToken, so clients get a 401.
+// UserID is the Bitbucket username and the token itself is forwarded to Bitbucket.
+// Credentials rewritten by BasicAuth are checked and forwarded with Basic auth.
+func BitbucketVerifier(client *bitbucket.Client, ttl, negativeTTL time.Duration) sdkauth.TokenVerifier {
+	c := &tokenCache{
+		client:      client,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     make(map[[sha256.Size]byte]cachedIdentity),
+	}
+	return c.verify
+}
+
+func (c *tokenCache) verify(ctx context.Context, token string, req *http.Request) (*sdkauth.TokenInfo, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: empty token", sdkauth.ErrInvalidToken)
+	}
+	opts := bitbucket.RequestOptsFromContext(ctx, token)
+	extra := map[string]any{ExtraBitbucketToken: token}
+	key := sha256.Sum256([]byte(token))
+	if basic, ok := basicFromRequest(req); ok {
+		opts.Username, opts.Token = basic.username, basic.password
+		extra = map[string]any{ExtraBitbucketToken: basic.password, ExtraBitbucketUser: basic.username}
+		// token is base64(user:password); keep its entry apart from an identical bearer token.
+		key = sha256.Sum256([]byte("basic:" + token))
+	}
+	id, ok := c.lookup(key)
+	if !ok {
+		user, err := c.client.GetCurrentUser(ctx, opts)
+		switch {
+		case errors.Is(err, bitbucket.ErrUnauthorized):
+			id = c.store(key, nil, c.negativeTTL)
+		case err != nil:
+			// Bitbucket unreachable or failing: not the token's fault, so nothing is cached.
+			return nil, fmt.Errorf("verify token: %w", err)
+		default:
+			id = c.store(key, user, c.ttl)
+		}
+	}
+	if id.user == nil {
+		return nil, fmt.Errorf("%w: rejected by Bitbucket", sdkauth.ErrInvalidToken)
+	}
+	extra[ExtraDisplayName] = id.user.DisplayName
+	return &sdkauth.TokenInfo{
+		UserID:     id.user.Name,
+		Expiration: id.expires,
+		// Bitbucket does not report a token's permissions; it enforces them on each call.
+		Scopes: []string{"REPO_READ", "REPO_WRITE", "PROJECT_READ"},
+		Extra:  extra,
+	}, nil
+}
+
+func (c *tokenCache) lookup(key [sha256.Size]byte) (cachedIdentity, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.entries[key]
+	if !ok || !c.now().Before(id.expires) {
+		return cachedIdentity{}, false
+	}
+	return id, true
+}
+
+func (c *tokenCache) store(key [sha256.Size]byte, user *bitbucket.User, ttl time.Duration) cachedIdentity {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= maxCachedTokens {
+		for k, id := range c.entries {
+			if !now.Before(id.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < maxCachedTokens {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	id := cachedIdentity{user: user, expires: now.Add(ttl)}
+	c.entries[key] = id
+	return id
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func newVerifyTest(t *testing.T) (*tokenCache, *atomic.Int32, *time.Time) {
+	t.Helper()
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if user, pass, ok := r.BasicAuth(); ok && user == "svc" && pass == "pw" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"svc","displayName":"Service"}`))
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer good":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"jdoe","displayName":"Jane Doe"}`))
+		case "Bearer flaky":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	now := time.Now()
+	c := &tokenCache{
+		client:      bitbucket.NewClient(ts.URL, nil, "off"),
+		ttl:         5 * time.Minute,
+		negativeTTL: time.Minute,
+		now:         func() time.Time { return now },
+		entries:     make(map[[sha256.Size]byte]cachedIdentity),
+	}
+	return c, &calls, &now
+}
+
+func TestBitbucketVerifier(t *testing.T) {
+	c, calls, now := newVerifyTest(t)
+
+	for range 2 {
+		info, err := c.verify(context.Background(), "good", nil)
+		if err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+		if info.UserID != "jdoe" || info.Extra[ExtraDisplayName] != "Jane Doe" || BitbucketToken(info) != "good" {
+			t.Errorf("info = %+v", info)
+		}
+		if !info.Expiration.Equal(now.Add(5 * time.Minute)) {
+			t.Errorf("Expiration = %v", info.Expiration)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Bitbucket called %d times, want 1", calls.Load())
+	}
+
+	*now = now.Add(6 * time.Minute)
+	if _, err := c.verify(context.Background(), "good", nil); err != nil || calls.Load() != 2 {
+		t.Errorf("after TTL: err = %v, calls = %d", err, calls.Load())
+	}
+}
+
+func TestBitbucketVerifier_Basic(t *testing.T) {
+	c, calls, _ := newVerifyTest(t)
+	var got *sdkauth.TokenInfo
+	var status int
+	h := BasicAuth("", "")(sdkauth.RequireBearerToken(c.verify, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = sdkauth.TokenInfoFromContext(r.Context())
+	})))
+	do := func(user, pass string) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.SetBasicAuth(user, pass)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		status = rec.Code
+	}
+
+	do("svc", "pw")
+	if status != http.StatusOK || got.UserID != "svc" || BitbucketUsername(got) != "svc" || BitbucketToken(got) != "pw" {
+		t.Errorf("status = %d, info = %+v", status, got)
+	}
+	do("svc", "wrong")
+	if status != http.StatusUnauthorized {
+		t.Errorf("wrong password: status = %d", status)
+	}
+	do("svc", "pw")
+	if calls.Load() != 2 {
+		t.Errorf("Bitbucket called %d times, want 2", calls.Load())
+	}
+}
+
+func TestBitbucketVerifier_Rejected(t *testing.T) {
+	c, calls, now := newVerifyTest(t)
+
+	for range 2 {
+		if _, err := c.verify(context.Background(), "bad", nil); !errors.Is(err, sdkauth.ErrInvalidToken) {
+			t.Fatalf("err = %v, want ErrInvalidToken", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Bitbucket called %d times, want 1 (negative cache)", calls.Load())
+	}
+	*now = now.Add(2 * time.Minute)
+	_, _ = c.verify(context.Background(), "bad", nil)
+	if calls.Load() != 2 {
+		t.Errorf("negative entry not expired: calls = %d", calls.Load())
+	}
+
+	if _, err := c.verify(context.Background(), "", nil); !errors.Is(err, sdkauth.ErrInvalidToken) {
+		t.Errorf("empty token: err = %v", err)
+	}
+}
+
+func TestBitbucketVerifier_BitbucketError(t *testing.T) {
+	c, calls, _ := newVerifyTest(t)
+
+	for range 2 {
+		_, err := c.verify(context.Background(), "flaky", nil)
+		if err == nil || errors.Is(err, sdkauth.ErrInvalidToken) {
+			t.Fatalf("err = %v, want a non-auth error", err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("failures were cached: calls = %d", calls.Load())
+	}
+}
+
+func TestTokenCache_Bounded(t *testing.T) {
+	c, _, now := newVerifyTest(t)
+	for i := range maxCachedTokens + 5 {
+		c.store(sha256.Sum256([]byte{byte(i), byte(i >> 8)}), nil, time.Minute)
+	}
+	if len(c.entries) > maxCachedTokens {
+		t.Errorf("entries = %d, want at most %d", len(c.entries), maxCachedTokens)
+	}
+	*now = now.Add(time.Hour)
+	c.store(sha256.Sum256([]byte("new")), nil, time.Minute)
+	if len(c.entries) != 1 {
+		t.Errorf("expired entries not pruned: %d left", len(c.entries))
+	}
+}
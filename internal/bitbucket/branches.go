@@ -58,3 +58,32 @@ func (c *Client) CreateBranch(ctx context.Context, projectKey, repoSlug string,
 	}
 	return &out, nil
 }
+
+// GetDefaultBranch returns the repository's default branch.
+func (c *Client) GetDefaultBranch(ctx context.Context, projectKey, repoSlug string, opts RequestOpts) (*Branch, error) {
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/branches/default"
+	var out Branch
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get default branch: %w", err)
+	}
+	return &out, nil
+}
+
+// GetBranch returns the branch with the given name (e.g. "main" or "refs/heads/main"),
+// or nil if the repository has no such branch.
+func (c *Client) GetBranch(ctx context.Context, projectKey, repoSlug, name string, opts RequestOpts) (*Branch, error) {
+	displayID := strings.TrimPrefix(name, "refs/heads/")
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/branches" +
+		pagedQuery(url.Values{"filterText": {displayID}}, "", 0, 100)
+	var out BranchesResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get branch: %w", err)
+	}
+	// filterText is a substring match; pick the exact branch.
+	for i := range out.Values {
+		if out.Values[i].DisplayID == displayID || out.Values[i].ID == "refs/heads/"+displayID {
+			return &out.Values[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -140,3 +140,88 @@ func TestCreateBranch_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestGetDefaultBranch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"refs/heads/main","displayId":"main","isDefault":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	branch, err := client.GetDefaultBranch(context.Background(), "PROJ", "repo", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetDefaultBranch: %v", err)
+	}
+	if branch.DisplayID != "main" {
+		t.Errorf("DisplayID = %q", branch.DisplayID)
+	}
+}
+
+func TestGetDefaultBranch_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/empty/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"no default branch"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.GetDefaultBranch(context.Background(), "PROJ", "empty", RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetBranch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filterText"); got != "fix" {
+			t.Errorf("filterText = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"refs/heads/fix-2","displayId":"fix-2","latestCommit":"bbb"},
+			{"id":"refs/heads/fix","displayId":"fix","latestCommit":"aaa"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	for _, name := range []string{"fix", "refs/heads/fix"} {
+		branch, err := client.GetBranch(context.Background(), "PROJ", "repo", name, RequestOpts{})
+		if err != nil {
+			t.Fatalf("GetBranch(%s): %v", name, err)
+		}
+		if branch == nil || branch.LatestCommit != "aaa" {
+			t.Errorf("GetBranch(%s) = %+v", name, branch)
+		}
+	}
+}
+
+func TestGetBranch_NotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"refs/heads/fix-2","displayId":"fix-2"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	branch, err := client.GetBranch(context.Background(), "PROJ", "repo", "fix", RequestOpts{})
+	if err != nil || branch != nil {
+		t.Errorf("GetBranch = %+v, %v; want nil, nil", branch, err)
+	}
+}
+
+func TestGetBranch_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetBranch(context.Background(), "PROJ", "repo", "fix", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
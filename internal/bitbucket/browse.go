@@ -2,9 +2,11 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 )
 
@@ -37,3 +39,104 @@ func (c *Client) GetFileContent(ctx context.Context, projectKey, repoSlug, fileP
 	}
 	return b.String(), nil
 }
+
+// escapePath escapes each segment of a repository path, keeping the slashes between them:
+// Bitbucket does not decode %2F in the files, raw and browse endpoints.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// FilesResponse is a page of file paths from the files API.
+type FilesResponse struct {
+	Values        []string `json:"values"`
+	Size          int      `json:"size"`
+	Limit         int      `json:"limit"`
+	IsLastPage    bool     `json:"isLastPage"`
+	Start         int      `json:"start"`
+	NextPageStart int      `json:"nextPageStart"`
+}
+
+// ListFiles returns a page of the paths of all files under dir (recursively) at ref.
+// Paths are relative to dir; an empty dir lists the whole repository.
+func (c *Client) ListFiles(ctx context.Context, projectKey, repoSlug, dir, ref string, start, limit int, opts RequestOpts) (*FilesResponse, error) {
+	apiPath := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/files"
+	if dir != "" {
+		apiPath += "/" + escapePath(dir)
+	}
+	q := url.Values{}
+	if ref != "" {
+		q.Set("at", ref)
+	}
+	var out FilesResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, apiPath+pagedQuery(q, "", start, limit), nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list files: %w", err)
+	}
+	return &out, nil
+}
+
+// GetRawFile returns the raw bytes of a file at ref, truncated to maxBytes when positive.
+func (c *Client) GetRawFile(ctx context.Context, projectKey, repoSlug, filePath, ref string, maxBytes int, opts RequestOpts) ([]byte, error) {
+	apiPath := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/raw/" + escapePath(filePath)
+	if ref != "" {
+		apiPath += "?at=" + url.QueryEscape(ref)
+	}
+	resp, err := c.doClient(ctx, c.api, http.MethodGet, apiPath, nil, opts)
+	if err != nil {
+		return nil, fmt.Errorf("get raw file: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("get raw file failed: %w", apiError(resp, ""))
+	}
+	body := resp.Body()
+	if maxBytes > 0 && len(body) > maxBytes {
+		body = body[:maxBytes]
+	}
+	return body, nil
+}
+
+// EditFileRequest describes a single-file commit made through the browse API.
+type EditFileRequest struct {
+	Content string
+	Message string
+	Branch  string
+	// SourceCommitID is the commit the edit is based on. Required when updating an
+	// existing file so that concurrent changes are rejected; omit it to create a file.
+	SourceCommitID string
+	// SourceBranch is the branch to create Branch from when Branch does not exist yet.
+	SourceBranch string
+}
+
+// EditFile creates or updates the file at filePath with a new commit on req.Branch.
+func (c *Client) EditFile(ctx context.Context, projectKey, repoSlug, filePath string, req EditFileRequest, opts RequestOpts) (*Commit, error) {
+	apiPath := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/browse/" + escapePath(filePath)
+	fields := map[string]string{"branch": req.Branch}
+	if req.Message != "" {
+		fields["message"] = req.Message
+	}
+	if req.SourceCommitID != "" {
+		fields["sourceCommitId"] = req.SourceCommitID
+	}
+	if req.SourceBranch != "" {
+		fields["sourceBranch"] = req.SourceBranch
+	}
+	form := multipartForm{
+		fields: fields,
+		files:  map[string]multipartFile{"content": {name: path.Base(filePath), content: []byte(req.Content)}},
+	}
+	resp, err := c.doClient(ctx, c.api, http.MethodPut, apiPath, form, opts)
+	if err != nil {
+		return nil, fmt.Errorf("edit file: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("edit file failed: %w", apiError(resp, ""))
+	}
+	var out Commit
+	if err := json.Unmarshal(resp.Body(), &out); err != nil {
+		return nil, fmt.Errorf("decode commit: %w", err)
+	}
+	return &out, nil
+}
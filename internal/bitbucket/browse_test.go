@@ -2,7 +2,9 @@ package bitbucket
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -78,3 +80,164 @@ func TestGetFileContent_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestEditFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/src/cmd/main.go", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		if r.Header.Get("X-Atlassian-Token") != "no-check" {
+			t.Error("missing X-Atlassian-Token header")
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		for field, want := range map[string]string{
+			"branch": "fix", "message": "Fix typo", "sourceCommitId": "abc", "sourceBranch": "master",
+		} {
+			if got := r.FormValue(field); got != want {
+				t.Errorf("%s = %q, want %q", field, got, want)
+			}
+		}
+		f, hdr, err := r.FormFile("content")
+		if err != nil {
+			t.Fatalf("content part: %v", err)
+		}
+		content, _ := io.ReadAll(f)
+		if string(content) != "package main\n" || hdr.Filename != "main.go" {
+			t.Errorf("content = %q (%s)", content, hdr.Filename)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"def456","displayId":"def456","message":"Fix typo","parents":[{"id":"abc"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	commit, err := client.EditFile(context.Background(), "PROJ", "repo", "src/cmd/main.go", EditFileRequest{
+		Content: "package main\n", Message: "Fix typo", Branch: "fix", SourceCommitID: "abc", SourceBranch: "master",
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("EditFile: %v", err)
+	}
+	if commit.ID != "def456" || len(commit.Parents) != 1 || commit.Parents[0].ID != "abc" {
+		t.Errorf("commit = %+v", commit)
+	}
+}
+
+func TestEditFile_NewFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/NEW.md", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if _, ok := r.MultipartForm.Value["sourceCommitId"]; ok {
+			t.Error("sourceCommitId sent for a new file")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.EditFile(context.Background(), "PROJ", "repo", "NEW.md", EditFileRequest{Content: "# New", Branch: "master"}, RequestOpts{}); err != nil {
+		t.Fatalf("EditFile: %v", err)
+	}
+}
+
+func TestEditFile_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"file has been modified since sourceCommitId"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.EditFile(context.Background(), "PROJ", "repo", "file.txt", EditFileRequest{Content: "x", Branch: "master", SourceCommitID: "old"}, RequestOpts{})
+	if err == nil || !strings.Contains(err.Error(), "409") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestEditFile_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if _, err := client.EditFile(context.Background(), "PROJ", "repo", "file.txt", EditFileRequest{Branch: "master"}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/files/src/cmd", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("at") != "develop" || q.Get("start") != "100" || q.Get("limit") != "50" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":["main.go","pkg/util.go"],"isLastPage":false,"nextPageStart":102}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListFiles(context.Background(), "PROJ", "repo", "src/cmd", "develop", 100, 50, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if len(resp.Values) != 2 || resp.NextPageStart != 102 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListFiles_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/files", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListFiles(context.Background(), "PROJ", "repo", "", "", 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetRawFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/raw/docs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/rest/api/1.0/projects/PROJ/repos/repo/raw/docs/user%20guide/README.md" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("at") != "main" {
+			t.Errorf("at = %q", r.URL.Query().Get("at"))
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("# Title\nbody\n"))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	content, err := client.GetRawFile(context.Background(), "PROJ", "repo", "docs/user guide/README.md", "main", 0, RequestOpts{})
+	if err != nil || string(content) != "# Title\nbody\n" {
+		t.Fatalf("GetRawFile = %q, %v", content, err)
+	}
+	content, err = client.GetRawFile(context.Background(), "PROJ", "repo", "docs/user guide/README.md", "main", 7, RequestOpts{})
+	if err != nil || string(content) != "# Title" {
+		t.Errorf("GetRawFile(max 7) = %q, %v", content, err)
+	}
+}
+
+func TestGetRawFile_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/raw/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetRawFile(context.Background(), "PROJ", "repo", "missing", "", 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,107 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Build states reported by CI servers.
+const (
+	BuildSuccessful = "SUCCESSFUL"
+	BuildFailed     = "FAILED"
+	BuildInProgress = "INPROGRESS"
+	BuildCancelled  = "CANCELLED"
+	BuildUnknown    = "UNKNOWN"
+)
+
+// BuildStatus is a CI result attached to a commit.
+type BuildStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	BuildNumber string `json:"buildNumber,omitempty"`
+	Ref         string `json:"ref,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	Duration    int64  `json:"duration,omitempty"` // milliseconds
+	DateAdded   int64  `json:"dateAdded,omitempty"`
+}
+
+// BuildStatusesResponse is the paginated API response for a commit's build statuses.
+type BuildStatusesResponse struct {
+	Values        []BuildStatus `json:"values"`
+	Size          int           `json:"size"`
+	Limit         int           `json:"limit"`
+	IsLastPage    bool          `json:"isLastPage"`
+	Start         int           `json:"start"`
+	NextPageStart int           `json:"nextPageStart"`
+}
+
+// ListBuildStatuses returns a page of the build statuses for a commit (rest/build-status/1.0).
+func (c *Client) ListBuildStatuses(ctx context.Context, commitID string, start int, opts RequestOpts) (*BuildStatusesResponse, error) {
+	path := "/commits/" + url.PathEscape(commitID) + pagedQuery(url.Values{}, "", start, 0)
+	var out BuildStatusesResponse
+	if err := c.doJSON(ctx, c.builds, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list build statuses: %w", err)
+	}
+	return &out, nil
+}
+
+// GetBuildStatus returns a single build status by key from the repository-scoped
+// builds endpoint (Bitbucket 7.14+).
+func (c *Client) GetBuildStatus(ctx context.Context, projectKey, repoSlug, commitID, key string, opts RequestOpts) (*BuildStatus, error) {
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) +
+		"/commits/" + url.PathEscape(commitID) + "/builds?key=" + url.QueryEscape(key)
+	var out BuildStatus
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get build status: %w", err)
+	}
+	return &out, nil
+}
+
+// PostBuildStatus records a build result for a commit. With a project and repository
+// it uses the repository-scoped builds endpoint (Bitbucket 7.4+), which accepts every
+// field; otherwise it falls back to rest/build-status/1.0, which ignores the newer ones.
+func (c *Client) PostBuildStatus(ctx context.Context, projectKey, repoSlug, commitID string, status BuildStatus, opts RequestOpts) error {
+	client, path := c.builds, "/commits/"+url.PathEscape(commitID)
+	if projectKey != "" && repoSlug != "" {
+		client = c.api
+		path = "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) +
+			"/commits/" + url.PathEscape(commitID) + "/builds"
+	}
+	resp, err := c.doClient(ctx, client, http.MethodPost, path, status, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("post build status failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
+
+// OverallBuildState folds individual build states into one: FAILED if any build
+// failed, INPROGRESS if any is still running, SUCCESSFUL if all succeeded, and
+// "" when there are no builds.
+func OverallBuildState(statuses []BuildStatus) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	state := BuildSuccessful
+	for _, s := range statuses {
+		switch s.State {
+		case BuildFailed, BuildCancelled:
+			return BuildFailed
+		case BuildInProgress:
+			state = BuildInProgress
+		case BuildSuccessful:
+		default:
+			if state == BuildSuccessful {
+				state = BuildUnknown
+			}
+		}
+	}
+	return state
+}
@@ -0,0 +1,170 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListBuildStatuses(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("start") != "25" {
+			t.Errorf("start = %q", r.URL.Query().Get("start"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"key":"ci","state":"SUCCESSFUL","url":"https://ci/1","name":"CI","dateAdded":1700000000000}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListBuildStatuses(context.Background(), "abc123", 25, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListBuildStatuses: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Key != "ci" || resp.Values[0].State != BuildSuccessful {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+}
+
+func TestListBuildStatuses_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListBuildStatuses(context.Background(), "abc123", 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetBuildStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc123/builds", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "ci" {
+			t.Errorf("key = %q", r.URL.Query().Get("key"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"ci","state":"FAILED","url":"https://ci/2","duration":12000,"buildNumber":"42"}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	status, err := client.GetBuildStatus(context.Background(), "PROJ", "repo", "abc123", "ci", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetBuildStatus: %v", err)
+	}
+	if status.State != BuildFailed || status.Duration != 12000 || status.BuildNumber != "42" {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestGetBuildStatus_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc123/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetBuildStatus(context.Background(), "PROJ", "repo", "abc123", "ci", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPostBuildStatus_RepositoryScoped(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc123/builds", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body BuildStatus
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.Key != "ci" || body.State != BuildInProgress || body.Duration != 500 {
+			t.Errorf("body = %+v", body)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.PostBuildStatus(context.Background(), "PROJ", "repo", "abc123",
+		BuildStatus{Key: "ci", State: BuildInProgress, URL: "https://ci/3", Duration: 500}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("PostBuildStatus: %v", err)
+	}
+}
+
+func TestPostBuildStatus_Legacy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.PostBuildStatus(context.Background(), "", "", "abc123",
+		BuildStatus{Key: "ci", State: BuildSuccessful, URL: "https://ci/3"}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("PostBuildStatus: %v", err)
+	}
+}
+
+func TestPostBuildStatus_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"state is invalid"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.PostBuildStatus(context.Background(), "", "", "abc123", BuildStatus{Key: "ci", State: "BOGUS"}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPostBuildStatus_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.PostBuildStatus(context.Background(), "", "", "abc123", BuildStatus{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestOverallBuildState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []string
+		want   string
+	}{
+		{"none", nil, ""},
+		{"all successful", []string{BuildSuccessful, BuildSuccessful}, BuildSuccessful},
+		{"one failed", []string{BuildSuccessful, BuildFailed, BuildInProgress}, BuildFailed},
+		{"cancelled counts as failed", []string{BuildCancelled}, BuildFailed},
+		{"in progress", []string{BuildSuccessful, BuildInProgress}, BuildInProgress},
+		{"unknown", []string{BuildSuccessful, BuildUnknown}, BuildUnknown},
+		{"in progress beats unknown", []string{BuildUnknown, BuildInProgress}, BuildInProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statuses []BuildStatus
+			for _, s := range tt.states {
+				statuses = append(statuses, BuildStatus{State: s})
+			}
+			if got := OverallBuildState(statuses); got != tt.want {
+				t.Errorf("OverallBuildState = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
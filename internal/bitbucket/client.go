@@ -1,10 +1,13 @@
 package bitbucket
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,51 +17,72 @@ import (
 
 // Client performs HTTP requests to Bitbucket Server REST API.
 type Client struct {
-	api    *resty.Client
-	search *resty.Client
+	api          *resty.Client
+	search       *resty.Client
+	builds       *resty.Client
+	insights     *resty.Client
+	restrictions *resty.Client
+	reviewers    *resty.Client
+	jira         *resty.Client
+	web          *resty.Client
 }
 
 // NewClient creates a Bitbucket API client. logLevel: "info" (default), "debug", or "off".
 func NewClient(baseURL string, extraHeaders map[string]string, logLevel string) *Client {
 	base := strings.TrimSuffix(baseURL, "/")
-	apiBase := base + "/rest/api/1.0"
-	searchBase := base + "/rest/search/1.0"
 
-	api := resty.New().
-		SetBaseURL(apiBase).
-		SetHeader("Content-Type", "application/json").
-		SetHeader("Accept", "application/json").
-		SetRetryCount(3).
-		SetRetryWaitTime(500 * time.Millisecond).
-		SetRetryMaxWaitTime(2 * time.Second)
+	enableDebug := logLevel == "debug" || (logLevel == "" && os.Getenv("BITBUCKET_DEBUG") != "")
+	var logger *debugLogger
+	if enableDebug {
+		logger = &debugLogger{log: log.Default()}
+	}
+
+	return &Client{
+		api:          newRestClient(base+"/rest/api/1.0", extraHeaders, logger),
+		search:       newRestClient(base+"/rest/search/1.0", extraHeaders, logger),
+		builds:       newRestClient(base+"/rest/build-status/1.0", extraHeaders, logger),
+		insights:     newRestClient(base+"/rest/insights/1.0", extraHeaders, logger),
+		restrictions: newRestClient(base+"/rest/branch-permissions/2.0", extraHeaders, logger),
+		reviewers:    newRestClient(base+"/rest/default-reviewers/1.0", extraHeaders, logger),
+		jira:         newRestClient(base+"/rest/jira/1.0", extraHeaders, logger),
+		web:          newRestClient(base, extraHeaders, logger),
+	}
+}
 
-	search := resty.New().
-		SetBaseURL(searchBase).
+// newRestClient creates a resty client for one Bitbucket base path with shared defaults.
+func newRestClient(baseURL string, extraHeaders map[string]string, logger *debugLogger) *resty.Client {
+	c := resty.New().
+		SetBaseURL(baseURL).
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Accept", "application/json").
 		SetRetryCount(3).
+		SetRetryResetReaders(true).
 		SetRetryWaitTime(500 * time.Millisecond).
 		SetRetryMaxWaitTime(2 * time.Second)
-
 	for k, v := range extraHeaders {
-		api.SetHeader(k, v)
-		search.SetHeader(k, v)
+		c.SetHeader(k, v)
 	}
-
-	enableDebug := logLevel == "debug" || (logLevel == "" && os.Getenv("BITBUCKET_DEBUG") != "")
-	if enableDebug {
-		logger := &debugLogger{log: log.Default()}
-		api.SetDebug(true).SetLogger(logger)
-		search.SetDebug(true).SetLogger(logger)
+	if logger != nil {
+		c.SetDebug(true).SetLogger(logger)
 	}
-
-	return &Client{api: api, search: search}
+	return c
 }
 
 // RequestOpts holds per-request options (token, proxied headers from context).
 type RequestOpts struct {
-	Token   string
-	Headers map[string]string
+	Token    string
+	Username string // when set, Username and Token are sent with HTTP Basic auth instead of as a bearer token
+	Headers  map[string]string
+}
+
+// setAuth adds the request's credentials: Basic auth when a username is given, else the bearer token.
+func (opts RequestOpts) setAuth(req *resty.Request) {
+	switch {
+	case opts.Username != "":
+		req.SetBasicAuth(opts.Username, opts.Token)
+	case opts.Token != "":
+		req.SetAuthToken(opts.Token)
+	}
 }
 
 // do performs an HTTP request to the Bitbucket API (rest/api/1.0).
@@ -71,16 +95,32 @@ func (c *Client) doSearch(ctx context.Context, method, apiPath string, body any,
 	return c.doClient(ctx, c.search, method, apiPath, body, opts)
 }
 
+// multipartForm is a request body sent as multipart/form-data instead of JSON.
+type multipartForm struct {
+	fields map[string]string
+	files  map[string]multipartFile // keyed by form field name
+}
+
+type multipartFile struct {
+	name    string
+	content []byte
+}
+
 func (c *Client) doClient(ctx context.Context, client *resty.Client, method, apiPath string, body any, opts RequestOpts) (*resty.Response, error) {
 	path := strings.TrimPrefix(apiPath, "/")
 	req := client.R().SetContext(ctx)
-	if opts.Token != "" {
-		req.SetAuthToken(opts.Token)
-	}
+	opts.setAuth(req)
 	for k, v := range opts.Headers {
 		req.SetHeader(k, v)
 	}
-	if body != nil {
+	if form, ok := body.(multipartForm); ok {
+		req.SetMultipartFormData(form.fields)
+		for field, f := range form.files {
+			req.SetMultipartField(field, f.name, "application/octet-stream", bytes.NewReader(f.content))
+		}
+		// Bitbucket rejects form posts without this header as a possible XSRF attack.
+		req.SetHeader("X-Atlassian-Token", "no-check")
+	} else if body != nil {
 		req.SetBody(body)
 	}
 
@@ -116,9 +156,7 @@ func apiError(resp *resty.Response, prefix string) error {
 func (c *Client) doJSON(ctx context.Context, client *resty.Client, method, path string, body, result any, opts RequestOpts) error {
 	path = strings.TrimPrefix(path, "/")
 	req := client.R().SetContext(ctx).SetResult(result)
-	if opts.Token != "" {
-		req.SetAuthToken(opts.Token)
-	}
+	opts.setAuth(req)
 	for k, v := range opts.Headers {
 		req.SetHeader(k, v)
 	}
@@ -149,6 +187,23 @@ func (c *Client) doJSON(ctx context.Context, client *resty.Client, method, path
 	return nil
 }
 
+// pagedQuery builds a query string with an optional filter and paging parameters.
+func pagedQuery(q url.Values, filter string, start, limit int) string {
+	if filter != "" {
+		q.Set("filter", filter)
+	}
+	if start > 0 {
+		q.Set("start", strconv.Itoa(start))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
 // debugLogger adapts *log.Logger to resty.Logger.
 type debugLogger struct{ log *log.Logger }
 
@@ -19,7 +19,7 @@ func newTestServer(handler http.Handler) (*Client, *httptest.Server) {
 
 func TestNewClient(t *testing.T) {
 	c := NewClient("https://bb.example.com", map[string]string{"X-Custom": "val"}, "off")
-	if c.api == nil || c.search == nil {
+	if c.api == nil || c.search == nil || c.builds == nil || c.insights == nil || c.restrictions == nil || c.reviewers == nil || c.jira == nil || c.web == nil {
 		t.Fatal("clients should not be nil")
 	}
 }
@@ -95,6 +95,26 @@ func TestDoClient_WithHeaders(t *testing.T) {
 	}
 }
 
+func TestDoClient_BasicAuth(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/test", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "svc" || pass != "secret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(200)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	opts := RequestOpts{Username: "svc", Token: "secret"}
+	if _, err := client.doClient(context.Background(), client.api, "GET", "/test", nil, opts); err != nil {
+		t.Fatalf("doClient: %v", err)
+	}
+	if err := client.doJSON(context.Background(), client.api, "GET", "/test", nil, nil, opts); err != nil {
+		t.Fatalf("doJSON: %v", err)
+	}
+}
+
 func TestDoClient_WithBody(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/test", func(w http.ResponseWriter, r *http.Request) {
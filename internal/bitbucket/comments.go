@@ -0,0 +1,84 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Comment is a comment on a pull request or commit. Replies are nested in Comments.
+type Comment struct {
+	ID          int            `json:"id"`
+	Version     int            `json:"version"`
+	Text        string         `json:"text"`
+	Author      *User          `json:"author,omitempty"`
+	CreatedDate int64          `json:"createdDate,omitempty"`
+	UpdatedDate int64          `json:"updatedDate,omitempty"`
+	Severity    string         `json:"severity,omitempty"` // NORMAL or BLOCKER
+	State       string         `json:"state,omitempty"`    // OPEN or RESOLVED
+	Anchor      *CommentAnchor `json:"anchor,omitempty"`
+	Comments    []Comment      `json:"comments,omitempty"`
+}
+
+// CommentAnchor attaches a comment to a file, or to a line of it when Line is set.
+type CommentAnchor struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	LineType string `json:"lineType,omitempty"` // ADDED, REMOVED or CONTEXT
+	FileType string `json:"fileType,omitempty"` // FROM or TO
+	DiffType string `json:"diffType,omitempty"` // COMMIT, EFFECTIVE or RANGE
+	FromHash string `json:"fromHash,omitempty"`
+	ToHash   string `json:"toHash,omitempty"`
+}
+
+// CommentParent references the comment a reply belongs to.
+type CommentParent struct {
+	ID int `json:"id"`
+}
+
+// CommentRequest is the request body for adding a comment, a reply (Parent set) or an
+// anchored comment (Anchor set).
+type CommentRequest struct {
+	Text   string         `json:"text"`
+	Parent *CommentParent `json:"parent,omitempty"`
+	Anchor *CommentAnchor `json:"anchor,omitempty"`
+}
+
+// CommentsResponse is the paged API response for comments.
+type CommentsResponse struct {
+	Values        []Comment `json:"values"`
+	Size          int       `json:"size"`
+	Limit         int       `json:"limit"`
+	IsLastPage    bool      `json:"isLastPage"`
+	Start         int       `json:"start"`
+	NextPageStart int       `json:"nextPageStart"`
+}
+
+func commitCommentsPath(projectKey, repoSlug, commitID string) string {
+	return "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) +
+		"/commits/" + url.PathEscape(commitID) + "/comments"
+}
+
+// ListCommitComments returns the comments on a commit, optionally only those on path.
+func (c *Client) ListCommitComments(ctx context.Context, projectKey, repoSlug, commitID, path string, start int, opts RequestOpts) (*CommentsResponse, error) {
+	q := url.Values{}
+	if path != "" {
+		q.Set("path", path)
+	}
+	var out CommentsResponse
+	reqPath := commitCommentsPath(projectKey, repoSlug, commitID) + pagedQuery(q, "", start, 0)
+	if err := c.doJSON(ctx, c.api, http.MethodGet, reqPath, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list commit comments: %w", err)
+	}
+	return &out, nil
+}
+
+// AddCommitComment adds a comment, reply or anchored comment to a commit.
+func (c *Client) AddCommitComment(ctx context.Context, projectKey, repoSlug, commitID string, comment CommentRequest, opts RequestOpts) (*Comment, error) {
+	var out Comment
+	if err := c.doJSON(ctx, c.api, http.MethodPost, commitCommentsPath(projectKey, repoSlug, commitID), comment, &out, opts); err != nil {
+		return nil, fmt.Errorf("add commit comment: %w", err)
+	}
+	return &out, nil
+}
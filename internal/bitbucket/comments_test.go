@@ -0,0 +1,91 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const commitCommentsURL = "/rest/api/1.0/projects/PROJ/repos/repo/commits/abc/comments"
+
+func TestListCommitComments(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("path"); got != "main.go" {
+			t.Errorf("path = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":1,"version":0,"text":"why?","author":{"name":"bob"},
+			"anchor":{"path":"main.go","line":7,"lineType":"ADDED","fileType":"TO"},
+			"comments":[{"id":2,"text":"hotfix"}]}],"size":1,"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListCommitComments(context.Background(), "PROJ", "repo", "abc", "main.go", 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListCommitComments: %v", err)
+	}
+	c := resp.Values[0]
+	if c.Anchor == nil || c.Anchor.Line != 7 || c.Author.Name != "bob" || len(c.Comments) != 1 || c.Comments[0].Text != "hotfix" {
+		t.Errorf("comment = %+v", c)
+	}
+}
+
+func TestListCommitComments_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListCommitComments(context.Background(), "PROJ", "repo", "abc", "", 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestAddCommitComment(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body CommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.Text != "reverted in #12" || body.Parent == nil || body.Parent.ID != 1 || body.Anchor != nil {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(`{"id":3,"text":"reverted in #12"}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	c, err := client.AddCommitComment(context.Background(), "PROJ", "repo", "abc", CommentRequest{
+		Text: "reverted in #12", Parent: &CommentParent{ID: 1},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("AddCommitComment: %v", err)
+	}
+	if c.ID != 3 {
+		t.Errorf("ID = %d", c.ID)
+	}
+}
+
+func TestAddCommitComment_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.AddCommitComment(context.Background(), "PROJ", "repo", "abc", CommentRequest{Text: "x"}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
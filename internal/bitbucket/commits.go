@@ -0,0 +1,66 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Commit represents a Bitbucket commit.
+type Commit struct {
+	ID                 string   `json:"id"`
+	DisplayID          string   `json:"displayId"`
+	Message            string   `json:"message"`
+	Author             *User    `json:"author,omitempty"`
+	AuthorTimestamp    int64    `json:"authorTimestamp,omitempty"`
+	Committer          *User    `json:"committer,omitempty"`
+	CommitterTimestamp int64    `json:"committerTimestamp,omitempty"`
+	Parents            []Commit `json:"parents,omitempty"`
+	// Properties carries plugin data; the Jira integration adds the linked issue keys.
+	Properties *CommitProperties `json:"properties,omitempty"`
+}
+
+// CommitProperties holds the commit properties we read.
+type CommitProperties struct {
+	JiraKeys []string `json:"jira-key,omitempty"`
+}
+
+// CommitsResponse is the paginated API response for listing commits.
+type CommitsResponse struct {
+	Values        []Commit `json:"values"`
+	Size          int      `json:"size"`
+	Limit         int      `json:"limit"`
+	IsLastPage    bool     `json:"isLastPage"`
+	Start         int      `json:"start"`
+	NextPageStart int      `json:"nextPageStart"`
+}
+
+// ListCommits returns a page of commits reachable from until (the default branch when
+// empty), newest first. A non-empty path limits them to commits touching that path.
+func (c *Client) ListCommits(ctx context.Context, projectKey, repoSlug, until, filePath string, start, limit int, opts RequestOpts) (*CommitsResponse, error) {
+	q := url.Values{}
+	if until != "" {
+		q.Set("until", until)
+	}
+	if filePath != "" {
+		q.Set("path", filePath)
+	}
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/commits" +
+		pagedQuery(q, "", start, limit)
+	var out CommitsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list commits: %w", err)
+	}
+	return &out, nil
+}
+
+// GetCommit returns a single commit.
+func (c *Client) GetCommit(ctx context.Context, projectKey, repoSlug, commitID string, opts RequestOpts) (*Commit, error) {
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/commits/" + url.PathEscape(commitID)
+	var out Commit
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get commit: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestListCommits(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("until") != "develop" || q.Get("path") != "src" || q.Get("limit") != "50" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"abc123","displayId":"abc","message":"Add flag","author":{"name":"alice"},"authorTimestamp":1700000000000}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListCommits(context.Background(), "PROJ", "repo", "develop", "src", 0, 50, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Message != "Add flag" || resp.Values[0].Author.Name != "alice" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListCommits_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListCommits(context.Background(), "PROJ", "repo", "", "", 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetCommit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"abc123","message":"ABC-1 fix","properties":{"jira-key":["ABC-1"]}}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	commit, err := client.GetCommit(context.Background(), "PROJ", "repo", "abc123", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetCommit: %v", err)
+	}
+	if commit.Properties == nil || len(commit.Properties.JiraKeys) != 1 || commit.Properties.JiraKeys[0] != "ABC-1" {
+		t.Errorf("commit = %+v", commit)
+	}
+}
+
+func TestGetCommit_Error(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, err := client.GetCommit(context.Background(), "PROJ", "repo", "missing", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
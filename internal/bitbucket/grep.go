@@ -0,0 +1,237 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Defaults bounding a GrepContent scan.
+const (
+	defaultGrepMaxFiles     = 200
+	defaultGrepMaxBytes     = 5 << 20
+	defaultGrepMaxFileBytes = 256 << 10
+	defaultGrepConcurrency  = 4
+	maxGrepConcurrency      = 16
+	maxGrepHitsPerFile      = 10
+	grepFilesPageSize       = 1000
+)
+
+// GrepOptions bounds a content scan of a few repositories. It is the fallback for
+// servers without the code search index (Bitbucket Data Center 7 and older).
+type GrepOptions struct {
+	Project      string
+	Repositories []string
+	Query        string   // files must contain this, ignoring case (when set)
+	AnyOf        []string // and at least one of these (when set)
+	Exclude      []string // skip files containing any of these, ignoring case
+	Ref          string   // default branch when empty
+	Path         string   // only files under this directory
+	Extension    string   // only files with this extension, with or without the leading dot
+	Limit        int      // stop after this many matching files; default 25
+	MaxFiles     int      // files read across all repositories; default 200
+	MaxBytes     int      // bytes read across all files; default 5 MiB
+	MaxFileBytes int      // bytes read per file; default 256 KiB
+	Concurrency  int      // parallel file reads; default 4
+}
+
+// GrepResponse is a SearchResponse produced by reading files rather than by the index.
+// It is always a single page.
+type GrepResponse struct {
+	SearchResponse
+	FilesScanned int   `json:"filesScanned"`
+	BytesScanned int64 `json:"bytesScanned"`
+	// Truncated reports that a limit stopped the scan before every candidate file was read.
+	Truncated bool `json:"truncated"`
+}
+
+type grepFile struct {
+	repo string
+	path string
+}
+
+// GrepContent reads the files of the given repositories through the raw API and
+// reports the lines containing any of the terms. All limits are enforced, so results
+// can be incomplete; Truncated says when they are.
+func (c *Client) GrepContent(ctx context.Context, grep GrepOptions, opts RequestOpts) (*GrepResponse, error) {
+	if grep.Project == "" || len(grep.Repositories) == 0 {
+		return nil, errors.New("grep needs a project and at least one repository")
+	}
+	var query [][]byte
+	if grep.Query != "" {
+		query = [][]byte{bytes.ToLower([]byte(grep.Query))}
+	}
+	anyOf, exclude := lowerTerms(grep.AnyOf), lowerTerms(grep.Exclude)
+	if len(query) == 0 && len(anyOf) == 0 {
+		return nil, errors.New("grep needs at least one search term")
+	}
+	// Like the index's "query AND (a OR b)": a file needs every part, and each line
+	// containing any of the terms is a hit.
+	terms := append(append([][]byte{}, query...), anyOf...)
+	grep.Limit = positiveOr(grep.Limit, defaultSearchLimit)
+	grep.MaxFiles = positiveOr(grep.MaxFiles, defaultGrepMaxFiles)
+	grep.MaxBytes = positiveOr(grep.MaxBytes, defaultGrepMaxBytes)
+	grep.MaxFileBytes = positiveOr(grep.MaxFileBytes, defaultGrepMaxFileBytes)
+	grep.Concurrency = min(positiveOr(grep.Concurrency, defaultGrepConcurrency), maxGrepConcurrency)
+
+	out := &GrepResponse{SearchResponse: SearchResponse{IsLastPage: true, Values: []SearchResult{}}}
+	files, truncated, err := c.grepCandidates(ctx, grep, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Truncated = truncated
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, grep.Concurrency)
+		errs []error
+	)
+	for _, f := range files {
+		sem <- struct{}{}
+		mu.Lock()
+		stop := out.BytesScanned >= int64(grep.MaxBytes) || len(out.Values) >= grep.Limit
+		if stop {
+			out.Truncated = true
+		}
+		mu.Unlock()
+		if stop {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(f grepFile) {
+			defer func() { <-sem; wg.Done() }()
+			content, err := c.GetRawFile(ctx, grep.Project, f.repo, f.path, grep.Ref, grep.MaxFileBytes, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if ctx.Err() == nil {
+					errs = append(errs, err)
+				}
+				return
+			}
+			out.FilesScanned++
+			out.BytesScanned += int64(len(content))
+			if len(out.Values) >= grep.Limit {
+				out.Truncated = true
+				cancel()
+				return
+			}
+			lower := bytes.ToLower(content)
+			if (len(query) > 0 && !containsAny(lower, query)) || (len(anyOf) > 0 && !containsAny(lower, anyOf)) || containsAny(lower, exclude) {
+				return
+			}
+			if hits := grepLines(content, terms); len(hits) > 0 {
+				out.Values = append(out.Values, SearchResult{
+					Repository:  &Repository{Slug: f.repo, Project: &Project{Key: grep.Project}},
+					File:        f.path,
+					HitContexts: hits,
+					HitCount:    len(hits),
+				})
+			}
+		}(f)
+	}
+	wg.Wait()
+	// Unreadable files are skipped; only fail when nothing could be read at all.
+	if out.FilesScanned == 0 && len(errs) > 0 {
+		return nil, errs[0]
+	}
+	sort.Slice(out.Values, func(i, j int) bool {
+		a, b := out.Values[i], out.Values[j]
+		if a.Repository.Slug != b.Repository.Slug {
+			return a.Repository.Slug < b.Repository.Slug
+		}
+		return a.File < b.File
+	})
+	out.Count = len(out.Values)
+	return out, nil
+}
+
+// grepCandidates lists the files to read, at most grep.MaxFiles across all repositories.
+// truncated reports that more files matched the path and extension filters.
+func (c *Client) grepCandidates(ctx context.Context, grep GrepOptions, opts RequestOpts) (files []grepFile, truncated bool, err error) {
+	dir := strings.Trim(grep.Path, "/")
+	ext := strings.TrimPrefix(grep.Extension, ".")
+	for _, repo := range grep.Repositories {
+		for start := 0; ; {
+			page, err := c.ListFiles(ctx, grep.Project, repo, dir, grep.Ref, start, grepFilesPageSize, opts)
+			if err != nil {
+				return nil, false, err
+			}
+			for _, p := range page.Values {
+				if ext != "" && strings.TrimPrefix(path.Ext(p), ".") != ext {
+					continue
+				}
+				if len(files) == grep.MaxFiles {
+					return files, true, nil
+				}
+				if dir != "" {
+					p = dir + "/" + p
+				}
+				files = append(files, grepFile{repo: repo, path: p})
+			}
+			if page.IsLastPage || page.NextPageStart <= start {
+				break
+			}
+			start = page.NextPageStart
+		}
+	}
+	return files, false, nil
+}
+
+// grepLines returns the lines of content containing any term, each as its own hit
+// context. Binary content yields no hits.
+func grepLines(content []byte, terms [][]byte) [][]SearchHitLine {
+	if bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0 {
+		return nil
+	}
+	var hits [][]SearchHitLine
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		lower := bytes.ToLower(line)
+		for _, t := range terms {
+			if bytes.Contains(lower, t) {
+				hits = append(hits, []SearchHitLine{{Line: i + 1, Text: strings.TrimRight(string(line), "\r")}})
+				break
+			}
+		}
+		if len(hits) == maxGrepHitsPerFile {
+			break
+		}
+	}
+	return hits
+}
+
+// lowerTerms lower-cases the non-empty terms.
+func lowerTerms(terms []string) [][]byte {
+	var out [][]byte
+	for _, t := range terms {
+		if t != "" {
+			out = append(out, bytes.ToLower([]byte(t)))
+		}
+	}
+	return out
+}
+
+// containsAny reports whether lower contains any of the lower-cased terms.
+func containsAny(lower []byte, terms [][]byte) bool {
+	for _, t := range terms {
+		if bytes.Contains(lower, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func positiveOr(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,196 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// grepMux serves files for PROJ/a and PROJ/b; raw reads are counted in reads.
+func grepMux(files map[string]map[string]string, reads *atomic.Int32) *http.ServeMux {
+	mux := http.NewServeMux()
+	for repo, contents := range files {
+		base := "/rest/api/1.0/projects/PROJ/repos/" + repo
+		mux.HandleFunc(base+"/files", func(w http.ResponseWriter, r *http.Request) {
+			var paths []string
+			for p := range contents {
+				paths = append(paths, `"`+p+`"`)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"values":[` + strings.Join(paths, ",") + `],"isLastPage":true}`))
+		})
+		mux.HandleFunc(base+"/raw/", func(w http.ResponseWriter, r *http.Request) {
+			reads.Add(1)
+			content, ok := contents[strings.TrimPrefix(r.URL.Path, base+"/raw/")]
+			if !ok {
+				w.WriteHeader(404)
+				return
+			}
+			_, _ = w.Write([]byte(content))
+		})
+	}
+	return mux
+}
+
+func TestGrepContent(t *testing.T) {
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{
+		"a": {"main.go": "package main\n\nfunc main() {\n\tNewClient()\n}\n", "README.md": "Use newclient to start\n", "bin.dat": "NewClient\x00\x01"},
+		"b": {"lib.go": "// nothing here\n", "client.go": "func NewClient() {}\r\n"},
+	}, &reads))
+	defer ts.Close()
+
+	resp, err := client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a", "b"}, Query: "NewClient", Extension: ".go",
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if resp.Truncated || resp.FilesScanned != 3 || resp.Count != 2 || !resp.IsLastPage {
+		t.Fatalf("resp = %+v", resp)
+	}
+	first, second := resp.Values[0], resp.Values[1]
+	if first.Repository.Slug != "a" || first.File != "main.go" || first.HitContexts[0][0].Line != 4 || first.Repository.Project.Key != "PROJ" {
+		t.Errorf("first = %+v", first)
+	}
+	if second.File != "client.go" || second.HitContexts[0][0].Text != "func NewClient() {}" {
+		t.Errorf("second = %+v", second)
+	}
+}
+
+func TestGrepContent_Exclude(t *testing.T) {
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{
+		"a": {"main.go": "NewClient()\n", "main_test.go": "// MOCK\nNewClient()\n"},
+	}, &reads))
+	defer ts.Close()
+
+	resp, err := client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "NewClient", Exclude: []string{"mock"},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if resp.FilesScanned != 2 || resp.Count != 1 || resp.Values[0].File != "main.go" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestGrepContent_QueryAndAnyOf(t *testing.T) {
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{
+		"a": {
+			"both.go":  "func NewClient() {\n\treturn dial()\n}\n",
+			"query.go": "NewClient()\n",
+			"other.go": "dial()\nretry()\n",
+		},
+	}, &reads))
+	defer ts.Close()
+
+	resp, err := client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "NewClient", AnyOf: []string{"dial", "retry"},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if resp.Count != 1 || resp.Values[0].File != "both.go" || resp.Values[0].HitCount != 2 {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	resp, err = client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, AnyOf: []string{"retry"},
+	}, RequestOpts{})
+	if err != nil || resp.Count != 1 || resp.Values[0].File != "other.go" {
+		t.Errorf("anyOf only: resp = %+v, err = %v", resp, err)
+	}
+}
+
+func TestGrepContent_CaseInsensitiveSkipsBinary(t *testing.T) {
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{
+		"a": {"README.md": "Use newclient to start\n", "bin.dat": "NewClient\x00\x01"},
+	}, &reads))
+	defer ts.Close()
+
+	resp, err := client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "NEWCLIENT",
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if resp.Count != 1 || resp.Values[0].File != "README.md" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestGrepContent_Limits(t *testing.T) {
+	files := map[string]string{}
+	for _, name := range []string{"1.txt", "2.txt", "3.txt", "4.txt", "5.txt", "6.txt"} {
+		files[name] = "match\n"
+	}
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{"a": files}, &reads))
+	defer ts.Close()
+
+	resp, err := client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "match", MaxFiles: 4, Concurrency: 2,
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if !resp.Truncated || resp.FilesScanned != 4 || reads.Load() != 4 {
+		t.Errorf("MaxFiles: truncated=%v scanned=%d reads=%d", resp.Truncated, resp.FilesScanned, reads.Load())
+	}
+
+	reads.Store(0)
+	resp, err = client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "match", MaxBytes: 6, Concurrency: 1,
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if !resp.Truncated || resp.BytesScanned != 6 || reads.Load() != 1 {
+		t.Errorf("MaxBytes: truncated=%v bytes=%d reads=%d", resp.Truncated, resp.BytesScanned, reads.Load())
+	}
+
+	resp, err = client.GrepContent(context.Background(), GrepOptions{
+		Project: "PROJ", Repositories: []string{"a"}, Query: "match", Limit: 2, Concurrency: 1,
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrepContent: %v", err)
+	}
+	if !resp.Truncated || resp.Count != 2 {
+		t.Errorf("Limit: truncated=%v count=%d", resp.Truncated, resp.Count)
+	}
+}
+
+func TestGrepContent_Errors(t *testing.T) {
+	var reads atomic.Int32
+	client, ts := newTestServer(grepMux(map[string]map[string]string{"a": {}}, &reads))
+	defer ts.Close()
+
+	for name, grep := range map[string]GrepOptions{
+		"no repositories": {Project: "PROJ", Query: "x"},
+		"no terms":        {Project: "PROJ", Repositories: []string{"a"}, Query: ""},
+		"unknown repo":    {Project: "PROJ", Repositories: []string{"missing"}, Query: "x"},
+	} {
+		if _, err := client.GrepContent(context.Background(), grep, RequestOpts{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGrepLines(t *testing.T) {
+	var b strings.Builder
+	for range maxGrepHitsPerFile + 5 {
+		b.WriteString("hit\n")
+	}
+	if got := grepLines([]byte(b.String()), [][]byte{[]byte("hit")}); len(got) != maxGrepHitsPerFile {
+		t.Errorf("got %d hits, want %d", len(got), maxGrepHitsPerFile)
+	}
+	if got := grepLines([]byte("alpha\nbeta\n"), [][]byte{[]byte("gamma"), []byte("beta")}); len(got) != 1 || got[0][0].Line != 2 {
+		t.Errorf("any-term hits = %+v", got)
+	}
+}
@@ -0,0 +1,114 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// maxAnnotationsPerRequest is Bitbucket's limit on annotations in one request (and per report).
+const maxAnnotationsPerRequest = 1000
+
+// InsightReport is a Code Insights report attached to a commit.
+type InsightReport struct {
+	Key         string        `json:"key,omitempty"`
+	Title       string        `json:"title"`
+	Details     string        `json:"details,omitempty"`
+	Result      string        `json:"result,omitempty"` // PASS or FAIL
+	Reporter    string        `json:"reporter,omitempty"`
+	Link        string        `json:"link,omitempty"`
+	LogoURL     string        `json:"logoUrl,omitempty"`
+	Data        []InsightData `json:"data,omitempty"`
+	CreatedDate int64         `json:"createdDate,omitempty"`
+}
+
+// InsightData is one data field shown on a report. Type is one of BOOLEAN, DATE,
+// DURATION, LINK, NUMBER, PERCENTAGE or TEXT; Value must match it.
+type InsightData struct {
+	Title string `json:"title"`
+	Type  string `json:"type,omitempty"`
+	Value any    `json:"value"`
+}
+
+// InsightAnnotation is a finding attached to a file line in a report.
+type InsightAnnotation struct {
+	Path       string `json:"path,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Message    string `json:"message"`
+	Severity   string `json:"severity"`       // LOW, MEDIUM or HIGH
+	Type       string `json:"type,omitempty"` // VULNERABILITY, CODE_SMELL or BUG
+	Link       string `json:"link,omitempty"`
+	ExternalID string `json:"externalId,omitempty"`
+}
+
+// InsightAnnotationsResponse is the API response for a report's annotations.
+type InsightAnnotationsResponse struct {
+	Annotations []InsightAnnotation `json:"annotations"`
+	TotalCount  int                 `json:"totalCount"`
+}
+
+func reportPath(projectKey, repoSlug, commitID, reportKey string) string {
+	return "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) +
+		"/commits/" + url.PathEscape(commitID) + "/reports/" + url.PathEscape(reportKey)
+}
+
+// CreateInsightReport creates or replaces the report with the given key on a commit.
+// Replacing a report deletes its annotations.
+func (c *Client) CreateInsightReport(ctx context.Context, projectKey, repoSlug, commitID, reportKey string, report InsightReport, opts RequestOpts) (*InsightReport, error) {
+	var out InsightReport
+	if err := c.doJSON(ctx, c.insights, http.MethodPut, reportPath(projectKey, repoSlug, commitID, reportKey), report, &out, opts); err != nil {
+		return nil, fmt.Errorf("create insight report: %w", err)
+	}
+	return &out, nil
+}
+
+// GetInsightReport returns a report by key.
+func (c *Client) GetInsightReport(ctx context.Context, projectKey, repoSlug, commitID, reportKey string, opts RequestOpts) (*InsightReport, error) {
+	var out InsightReport
+	if err := c.doJSON(ctx, c.insights, http.MethodGet, reportPath(projectKey, repoSlug, commitID, reportKey), nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get insight report: %w", err)
+	}
+	return &out, nil
+}
+
+// DeleteInsightReport deletes a report and its annotations.
+func (c *Client) DeleteInsightReport(ctx context.Context, projectKey, repoSlug, commitID, reportKey string, opts RequestOpts) error {
+	resp, err := c.doClient(ctx, c.insights, http.MethodDelete, reportPath(projectKey, repoSlug, commitID, reportKey), nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("delete insight report failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
+
+// AddInsightAnnotations adds annotations to an existing report, in batches of at most 1000.
+func (c *Client) AddInsightAnnotations(ctx context.Context, projectKey, repoSlug, commitID, reportKey string, annotations []InsightAnnotation, opts RequestOpts) error {
+	path := reportPath(projectKey, repoSlug, commitID, reportKey) + "/annotations"
+	for start := 0; start < len(annotations); start += maxAnnotationsPerRequest {
+		end := min(start+maxAnnotationsPerRequest, len(annotations))
+		body := struct {
+			Annotations []InsightAnnotation `json:"annotations"`
+		}{annotations[start:end]}
+		resp, err := c.doClient(ctx, c.insights, http.MethodPost, path, body, opts)
+		if err != nil {
+			return err
+		}
+		if resp.IsError() {
+			return fmt.Errorf("add insight annotations failed: %w", apiError(resp, ""))
+		}
+	}
+	return nil
+}
+
+// ListInsightAnnotations returns the annotations of a report.
+func (c *Client) ListInsightAnnotations(ctx context.Context, projectKey, repoSlug, commitID, reportKey string, opts RequestOpts) (*InsightAnnotationsResponse, error) {
+	var out InsightAnnotationsResponse
+	path := reportPath(projectKey, repoSlug, commitID, reportKey) + "/annotations"
+	if err := c.doJSON(ctx, c.insights, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list insight annotations: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,212 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const insightsReportPath = "/rest/insights/1.0/projects/PROJ/repos/repo/commits/abc/reports/lint"
+
+func TestCreateInsightReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body InsightReport
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.Title != "Lint" || body.Result != "FAIL" || len(body.Data) != 1 || body.Data[0].Type != "NUMBER" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint","result":"FAIL","data":[{"title":"Issues","type":"NUMBER","value":3}],"createdDate":1700000000000}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	report, err := client.CreateInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", InsightReport{
+		Title: "Lint", Result: "FAIL", Data: []InsightData{{Title: "Issues", Type: "NUMBER", Value: 3}},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("CreateInsightReport: %v", err)
+	}
+	if report.Key != "lint" || report.CreatedDate == 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestCreateInsightReport_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"title is required"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.CreateInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", InsightReport{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetInsightReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint","result":"PASS"}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	report, err := client.GetInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetInsightReport: %v", err)
+	}
+	if report.Result != "PASS" {
+		t.Errorf("Result = %q", report.Result)
+	}
+}
+
+func TestGetInsightReport_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteInsightReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{}); err != nil {
+		t.Fatalf("DeleteInsightReport: %v", err)
+	}
+}
+
+func TestDeleteInsightReport_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteInsightReport_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.DeleteInsightReport(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestAddInsightAnnotations_Batches(t *testing.T) {
+	var batches []int
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body struct {
+			Annotations []InsightAnnotation `json:"annotations"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		batches = append(batches, len(body.Annotations))
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	annotations := make([]InsightAnnotation, 1500)
+	for i := range annotations {
+		annotations[i] = InsightAnnotation{Path: "main.go", Line: i + 1, Message: fmt.Sprintf("issue %d", i), Severity: "LOW"}
+	}
+	if err := client.AddInsightAnnotations(context.Background(), "PROJ", "repo", "abc", "lint", annotations, RequestOpts{}); err != nil {
+		t.Fatalf("AddInsightAnnotations: %v", err)
+	}
+	if len(batches) != 2 || batches[0] != 1000 || batches[1] != 500 {
+		t.Errorf("batches = %v", batches)
+	}
+}
+
+func TestAddInsightAnnotations_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.AddInsightAnnotations(context.Background(), "PROJ", "repo", "abc", "lint",
+		[]InsightAnnotation{{Message: "m", Severity: "EXTREME"}}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestAddInsightAnnotations_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	err := client.AddInsightAnnotations(context.Background(), "PROJ", "repo", "abc", "lint",
+		[]InsightAnnotation{{Message: "m", Severity: "LOW"}}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListInsightAnnotations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"annotations":[{"path":"a.go","line":3,"message":"unused","severity":"LOW","type":"CODE_SMELL"}],"totalCount":1}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListInsightAnnotations(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListInsightAnnotations: %v", err)
+	}
+	if resp.TotalCount != 1 || resp.Annotations[0].Type != "CODE_SMELL" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListInsightAnnotations_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListInsightAnnotations(context.Background(), "PROJ", "repo", "abc", "lint", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
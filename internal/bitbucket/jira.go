@@ -0,0 +1,63 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// ErrJiraUnavailable is returned when the server has no Jira integration
+// (the /rest/jira/1.0 endpoints answer 404).
+var ErrJiraUnavailable = errors.New("jira integration is not available on this Bitbucket server")
+
+// JiraIssue is a Jira issue linked to a pull request.
+type JiraIssue struct {
+	Key string `json:"key"`
+	URL string `json:"url,omitempty"`
+}
+
+// GetPullRequestIssues returns the Jira issues linked to a pull request by the Jira
+// integration (rest/jira/1.0).
+func (c *Client) GetPullRequestIssues(ctx context.Context, projectKey, repoSlug string, prID int, opts RequestOpts) ([]JiraIssue, error) {
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/issues",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), prID)
+	resp, err := c.doClient(ctx, c.jira, http.MethodGet, path, nil, opts)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request issues: %w", err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrJiraUnavailable
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("get pull request issues failed: %w", apiError(resp, ""))
+	}
+	out := []JiraIssue{}
+	if err := json.Unmarshal(resp.Body(), &out); err != nil {
+		return nil, fmt.Errorf("decode pull request issues: %w", err)
+	}
+	return out, nil
+}
+
+// issueKeyPattern matches Jira issue keys such as ABC-123, as Jira does: a project key
+// starting with an uppercase letter, a dash and a number, not inside a longer word.
+var issueKeyPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_])([A-Z][A-Z0-9_]+-[1-9][0-9]*)\b`)
+
+// ExtractIssueKeys returns the distinct Jira issue keys mentioned in texts (branch names,
+// titles, commit messages), in order of first appearance.
+func ExtractIssueKeys(texts ...string) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range issueKeyPattern.FindAllStringSubmatch(text, -1) {
+			if key := m[1]; !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
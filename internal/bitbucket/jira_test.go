@@ -0,0 +1,68 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const prIssuesPath = "/rest/jira/1.0/projects/PROJ/repos/repo/pull-requests/5/issues"
+
+func TestGetPullRequestIssues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prIssuesPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"key":"ABC-1","url":"https://jira.example.com/browse/ABC-1"},{"key":"XYZ-22","url":"https://jira.example.com/browse/XYZ-22"}]`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	issues, err := client.GetPullRequestIssues(context.Background(), "PROJ", "repo", 5, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetPullRequestIssues: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Key != "ABC-1" || issues[1].URL != "https://jira.example.com/browse/XYZ-22" {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestGetPullRequestIssues_Unavailable(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, err := client.GetPullRequestIssues(context.Background(), "PROJ", "repo", 5, RequestOpts{}); !errors.Is(err, ErrJiraUnavailable) {
+		t.Fatalf("err = %v, want ErrJiraUnavailable", err)
+	}
+}
+
+func TestGetPullRequestIssues_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prIssuesPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.GetPullRequestIssues(context.Background(), "PROJ", "repo", 5, RequestOpts{})
+	if err == nil || errors.Is(err, ErrJiraUnavailable) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestExtractIssueKeys(t *testing.T) {
+	got := ExtractIssueKeys(
+		"feature/ABC-123-login",
+		"ABC-123: Add login (fixes XY_Z-9, refs abc-4)",
+		"Merge ABCD-0 and PROJ2-77\n\nSee https://jira/browse/OPS-5.",
+		"notAKEY-1 KEY-12x",
+	)
+	want := []string{"ABC-123", "XY_Z-9", "PROJ2-77", "OPS-5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractIssueKeys = %v, want %v", got, want)
+	}
+	if got := ExtractIssueKeys("no keys here"); got == nil || len(got) != 0 {
+		t.Errorf("ExtractIssueKeys(none) = %v, want empty slice", got)
+	}
+}
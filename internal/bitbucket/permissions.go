@@ -0,0 +1,138 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Group represents a Bitbucket user group.
+type Group struct {
+	Name string `json:"name"`
+}
+
+// UserPermission is a permission granted directly to a user.
+type UserPermission struct {
+	User       *User  `json:"user"`
+	Permission string `json:"permission"`
+}
+
+// GroupPermission is a permission granted to a group.
+type GroupPermission struct {
+	Group      *Group `json:"group"`
+	Permission string `json:"permission"`
+}
+
+// UserPermissionsResponse is the paginated API response for user permissions.
+type UserPermissionsResponse struct {
+	Values        []UserPermission `json:"values"`
+	Size          int              `json:"size"`
+	Limit         int              `json:"limit"`
+	IsLastPage    bool             `json:"isLastPage"`
+	Start         int              `json:"start"`
+	NextPageStart int              `json:"nextPageStart"`
+}
+
+// GroupPermissionsResponse is the paginated API response for group permissions.
+type GroupPermissionsResponse struct {
+	Values        []GroupPermission `json:"values"`
+	Size          int               `json:"size"`
+	Limit         int               `json:"limit"`
+	IsLastPage    bool              `json:"isLastPage"`
+	Start         int               `json:"start"`
+	NextPageStart int               `json:"nextPageStart"`
+}
+
+// PermissionRank orders permissions by strength regardless of scope:
+// READ (1) < WRITE (2) < ADMIN (3); unknown permissions rank 0.
+// PROJECT_WRITE therefore ranks the same as REPO_WRITE, which it implies.
+func PermissionRank(permission string) int {
+	p := strings.ToUpper(permission)
+	p = strings.TrimPrefix(p, "PROJECT_")
+	p = strings.TrimPrefix(p, "REPO_")
+	switch p {
+	case "READ":
+		return 1
+	case "WRITE":
+		return 2
+	case "ADMIN":
+		return 3
+	}
+	return 0
+}
+
+// NormalizePermission prefixes a bare READ/WRITE/ADMIN with the scope
+// (REPO_ when repoSlug is set, PROJECT_ otherwise). Full names pass through.
+func NormalizePermission(repoSlug, permission string) string {
+	p := strings.ToUpper(strings.TrimSpace(permission))
+	if strings.HasPrefix(p, "REPO_") || strings.HasPrefix(p, "PROJECT_") {
+		return p
+	}
+	if repoSlug != "" {
+		return "REPO_" + p
+	}
+	return "PROJECT_" + p
+}
+
+// permissionsPath returns the project or (when repoSlug is set) repository permissions path for kind ("users" or "groups").
+func permissionsPath(projectKey, repoSlug, kind string) string {
+	path := "/projects/" + url.PathEscape(projectKey)
+	if repoSlug != "" {
+		path += "/repos/" + url.PathEscape(repoSlug)
+	}
+	return path + "/permissions/" + kind
+}
+
+// ListUserPermissions returns users with explicit permissions on a project, or on a repository when repoSlug is set.
+func (c *Client) ListUserPermissions(ctx context.Context, projectKey, repoSlug, filter string, start int, opts RequestOpts) (*UserPermissionsResponse, error) {
+	path := permissionsPath(projectKey, repoSlug, "users") + pagedQuery(url.Values{}, filter, start, 0)
+	var out UserPermissionsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list user permissions: %w", err)
+	}
+	return &out, nil
+}
+
+// ListGroupPermissions returns groups with explicit permissions on a project, or on a repository when repoSlug is set.
+func (c *Client) ListGroupPermissions(ctx context.Context, projectKey, repoSlug, filter string, start int, opts RequestOpts) (*GroupPermissionsResponse, error) {
+	path := permissionsPath(projectKey, repoSlug, "groups") + pagedQuery(url.Values{}, filter, start, 0)
+	var out GroupPermissionsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list group permissions: %w", err)
+	}
+	return &out, nil
+}
+
+// GrantPermission grants permission to the named users or groups (kind "users" or "groups").
+// Granting replaces any permission the principal already held at this scope.
+func (c *Client) GrantPermission(ctx context.Context, projectKey, repoSlug, kind string, names []string, permission string, opts RequestOpts) error {
+	q := url.Values{}
+	for _, n := range names {
+		q.Add("name", n)
+	}
+	q.Set("permission", permission)
+	path := permissionsPath(projectKey, repoSlug, kind) + "?" + q.Encode()
+	resp, err := c.do(ctx, http.MethodPut, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("grant permission failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
+
+// RevokePermission revokes all permissions at this scope from a user or group (kind "users" or "groups").
+func (c *Client) RevokePermission(ctx context.Context, projectKey, repoSlug, kind, name string, opts RequestOpts) error {
+	path := permissionsPath(projectKey, repoSlug, kind) + "?name=" + url.QueryEscape(name)
+	resp, err := c.do(ctx, http.MethodDelete, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("revoke permission failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPermissionRank(t *testing.T) {
+	tests := map[string]int{
+		"REPO_READ": 1, "PROJECT_READ": 1, "read": 1,
+		"REPO_WRITE": 2, "PROJECT_WRITE": 2,
+		"REPO_ADMIN": 3, "PROJECT_ADMIN": 3, "ADMIN": 3,
+		"PROJECT_CREATE": 0, "": 0,
+	}
+	for in, want := range tests {
+		if got := PermissionRank(in); got != want {
+			t.Errorf("PermissionRank(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestNormalizePermission(t *testing.T) {
+	tests := []struct{ repo, in, want string }{
+		{"repo", "write", "REPO_WRITE"},
+		{"", "write", "PROJECT_WRITE"},
+		{"repo", "REPO_ADMIN", "REPO_ADMIN"},
+		{"", "project_read", "PROJECT_READ"},
+	}
+	for _, tt := range tests {
+		if got := NormalizePermission(tt.repo, tt.in); got != tt.want {
+			t.Errorf("NormalizePermission(%q, %q) = %q, want %q", tt.repo, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestListUserPermissions_Repository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") != "ali" || r.URL.Query().Get("start") != "25" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"user":{"name":"alice","displayName":"Alice"},"permission":"REPO_WRITE"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListUserPermissions(context.Background(), "PROJ", "repo", "ali", 25, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListUserPermissions: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].User.Name != "alice" || resp.Values[0].Permission != "REPO_WRITE" {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+}
+
+func TestListUserPermissions_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.ListUserPermissions(context.Background(), "PROJ", "", "", 0, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListGroupPermissions_Project(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("query = %q, want empty", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"group":{"name":"devs"},"permission":"PROJECT_WRITE"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListGroupPermissions(context.Background(), "PROJ", "", "", 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListGroupPermissions: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Group.Name != "devs" {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+}
+
+func TestListGroupPermissions_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.ListGroupPermissions(context.Background(), "PROJ", "repo", "dev", 5, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGrantPermission(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		q := r.URL.Query()
+		if names := q["name"]; len(names) != 2 || names[0] != "alice" || names[1] != "bob" {
+			t.Errorf("name = %v", names)
+		}
+		if q.Get("permission") != "REPO_WRITE" {
+			t.Errorf("permission = %q", q.Get("permission"))
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.GrantPermission(context.Background(), "PROJ", "repo", "users", []string{"alice", "bob"}, "REPO_WRITE", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GrantPermission: %v", err)
+	}
+}
+
+func TestGrantPermission_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"invalid permission"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	err := client.GrantPermission(context.Background(), "PROJ", "", "groups", []string{"devs"}, "REPO_WRITE", RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGrantPermission_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	err := client.GrantPermission(context.Background(), "PROJ", "", "users", []string{"a"}, "PROJECT_READ", RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRevokePermission(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		if r.URL.Query().Get("name") != "old team" {
+			t.Errorf("name = %q", r.URL.Query().Get("name"))
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.RevokePermission(context.Background(), "PROJ", "", "groups", "old team", RequestOpts{}); err != nil {
+		t.Fatalf("RevokePermission: %v", err)
+	}
+}
+
+func TestRevokePermission_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"cannot revoke own admin"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.RevokePermission(context.Background(), "PROJ", "repo", "users", "me", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRevokePermission_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.RevokePermission(context.Background(), "PROJ", "", "users", "a", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Project represents a Bitbucket project with its full details.
+type Project struct {
+	Key         string `json:"key"`
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Public      bool   `json:"public"`
+	Type        string `json:"type,omitempty"` // NORMAL or PERSONAL
+	AvatarURL   string `json:"avatarUrl,omitempty"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// projectAvatarSize is the avatar size requested so responses carry an avatarUrl.
+const projectAvatarSize = 64
+
+// GetProject returns project details, including a link to its avatar.
+func (c *Client) GetProject(ctx context.Context, projectKey string, opts RequestOpts) (*Project, error) {
+	path := fmt.Sprintf("/projects/%s?avatarSize=%d", url.PathEscape(projectKey), projectAvatarSize)
+	var out Project
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	return &out, nil
+}
+
+// CreateProjectRequest is the request body for creating a project.
+type CreateProjectRequest struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Avatar      string `json:"avatar,omitempty"` // data URI, e.g. data:image/png;base64,...
+}
+
+// CreateProject creates a new project. Requires PROJECT_CREATE permission.
+func (c *Client) CreateProject(ctx context.Context, req CreateProjectRequest, opts RequestOpts) (*Project, error) {
+	var out Project
+	if err := c.doJSON(ctx, c.api, http.MethodPost, "/projects", req, &out, opts); err != nil {
+		return nil, fmt.Errorf("create project: %w", err)
+	}
+	return &out, nil
+}
+
+// UpdateProjectRequest is the request body for updating a project. Empty or nil
+// fields are left unchanged; setting Key renames the project key.
+type UpdateProjectRequest struct {
+	Key         string  `json:"key,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Public      *bool   `json:"public,omitempty"`
+	Avatar      string  `json:"avatar,omitempty"`
+}
+
+// UpdateProject updates a project. Requires PROJECT_ADMIN permission.
+func (c *Client) UpdateProject(ctx context.Context, projectKey string, req UpdateProjectRequest, opts RequestOpts) (*Project, error) {
+	path := "/projects/" + url.PathEscape(projectKey)
+	var out Project
+	if err := c.doJSON(ctx, c.api, http.MethodPut, path, req, &out, opts); err != nil {
+		return nil, fmt.Errorf("update project: %w", err)
+	}
+	return &out, nil
+}
+
+// DeleteProject deletes a project. Bitbucket refuses if the project still has repositories.
+func (c *Client) DeleteProject(ctx context.Context, projectKey string, opts RequestOpts) error {
+	path := "/projects/" + url.PathEscape(projectKey)
+	resp, err := c.do(ctx, http.MethodDelete, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("delete project failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("avatarSize") != "64" {
+			t.Errorf("avatarSize = %q", r.URL.Query().Get("avatarSize"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ","id":7,"name":"Project","description":"Core services","public":true,"type":"NORMAL",
+			"avatarUrl":"/projects/PROJ/avatar.png?s=64","links":{"self":[{"href":"https://bb.example.com/projects/PROJ"}]}}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	p, err := client.GetProject(context.Background(), "PROJ", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if p.Key != "PROJ" || p.ID != 7 || p.Description != "Core services" || !p.Public || p.Type != "NORMAL" {
+		t.Errorf("project = %+v", p)
+	}
+	if p.AvatarURL == "" {
+		t.Error("expected avatarUrl")
+	}
+}
+
+func TestGetProject_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/NOPE", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.GetProject(context.Background(), "NOPE", RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body CreateProjectRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.Key != "NEW" || body.Name != "New" || body.Description != "desc" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(`{"key":"NEW","id":8,"name":"New","description":"desc","type":"NORMAL"}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	p, err := client.CreateProject(context.Background(), CreateProjectRequest{Key: "NEW", Name: "New", Description: "desc"}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if p.ID != 8 {
+		t.Errorf("ID = %d", p.ID)
+	}
+}
+
+func TestCreateProject_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"key already in use"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.CreateProject(context.Background(), CreateProjectRequest{Key: "NEW", Name: "New"}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUpdateProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if _, ok := body["name"]; ok {
+			t.Errorf("name should be omitted: %v", body)
+		}
+		if body["description"] != "" || body["public"] != true {
+			t.Errorf("body = %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ","name":"Project","description":"","public":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	empty, public := "", true
+	p, err := client.UpdateProject(context.Background(), "PROJ", UpdateProjectRequest{Description: &empty, Public: &public}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if !p.Public {
+		t.Error("expected public project")
+	}
+}
+
+func TestUpdateProject_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.UpdateProject(context.Background(), "PROJ", UpdateProjectRequest{Name: "x"}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OLD", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteProject(context.Background(), "OLD", RequestOpts{}); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+}
+
+func TestDeleteProject_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OLD", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"project has repositories"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteProject(context.Background(), "OLD", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteProject_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.DeleteProject(context.Background(), "OLD", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
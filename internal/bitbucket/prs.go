@@ -20,13 +20,56 @@ type PullRequest struct {
 	FromRef     *Ref   `json:"fromRef"`
 	ToRef       *Ref   `json:"toRef"`
 	Author      *User  `json:"author"`
+	CreatedDate int64  `json:"createdDate,omitempty"`
+	UpdatedDate int64  `json:"updatedDate,omitempty"`
+}
+
+// PullRequestsResponse is the paginated API response for listing pull requests.
+type PullRequestsResponse struct {
+	Values        []PullRequest `json:"values"`
+	Size          int           `json:"size"`
+	Limit         int           `json:"limit"`
+	IsLastPage    bool          `json:"isLastPage"`
+	Start         int           `json:"start"`
+	NextPageStart int           `json:"nextPageStart"`
+}
+
+// PullRequestListOptions filters a repository's pull request list.
+type PullRequestListOptions struct {
+	State      string // OPEN (server default), DECLINED, MERGED or ALL
+	FilterText string // matches the title, description and branch names
+	Order      string // NEWEST (default) or OLDEST
+	Start      int
+	Limit      int
+}
+
+// ListPullRequests returns a page of a repository's pull requests.
+func (c *Client) ListPullRequests(ctx context.Context, projectKey, repoSlug string, list PullRequestListOptions, opts RequestOpts) (*PullRequestsResponse, error) {
+	q := url.Values{}
+	if list.State != "" {
+		q.Set("state", list.State)
+	}
+	if list.FilterText != "" {
+		q.Set("filterText", list.FilterText)
+	}
+	if list.Order != "" {
+		q.Set("order", list.Order)
+	}
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/pull-requests" +
+		pagedQuery(q, "", list.Start, list.Limit)
+	var out PullRequestsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list pull requests: %w", err)
+	}
+	return &out, nil
 }
 
 // Ref represents a branch reference.
 type Ref struct {
-	ID         string       `json:"id"`
-	DisplayID  string       `json:"displayId"`
-	Repository *Repository  `json:"repository"`
+	ID           string      `json:"id"`
+	DisplayID    string      `json:"displayId"`
+	LatestCommit string      `json:"latestCommit,omitempty"`
+	Repository   *Repository `json:"repository"`
 }
 
 // RefInput is a minimal ref for create PR (repository with project key).
@@ -106,6 +149,42 @@ func (c *Client) MergePullRequest(ctx context.Context, projectKey, repoSlug stri
 	return nil
 }
 
+// MergeStatus reports whether a pull request can be merged and, if not, which checks veto it.
+type MergeStatus struct {
+	CanMerge   bool        `json:"canMerge"`
+	Conflicted bool        `json:"conflicted"`
+	Outcome    string      `json:"outcome,omitempty"` // CLEAN, CONFLICTED or UNKNOWN
+	Vetoes     []MergeVeto `json:"vetoes"`
+}
+
+// MergeVeto is a merge check that currently blocks a pull request.
+type MergeVeto struct {
+	SummaryMessage  string `json:"summaryMessage"`
+	DetailedMessage string `json:"detailedMessage,omitempty"`
+}
+
+// GetMergeStatus tests whether a pull request can be merged without merging it.
+func (c *Client) GetMergeStatus(ctx context.Context, projectKey, repoSlug string, prID int, opts RequestOpts) (*MergeStatus, error) {
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/merge",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), prID)
+	var out MergeStatus
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get merge status: %w", err)
+	}
+	return &out, nil
+}
+
+// ListPullRequestCommits returns a page of the commits a pull request would merge.
+func (c *Client) ListPullRequestCommits(ctx context.Context, projectKey, repoSlug string, prID, start, limit int, opts RequestOpts) (*CommitsResponse, error) {
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/commits",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), prID) + pagedQuery(url.Values{}, "", start, limit)
+	var out CommitsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list pull request commits: %w", err)
+	}
+	return &out, nil
+}
+
 // DeclinePullRequest declines a pull request.
 func (c *Client) DeclinePullRequest(ctx context.Context, projectKey, repoSlug string, prID, version int, opts RequestOpts) error {
 	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/decline?version=%d",
@@ -134,16 +213,11 @@ func (c *Client) GetPullRequestDiff(ctx context.Context, projectKey, repoSlug st
 	return resp.String(), nil
 }
 
-// AddPRCommentRequest is the request body for adding a PR comment.
-type AddPRCommentRequest struct {
-	Text string `json:"text"`
-}
-
 // AddPullRequestComment adds a general comment to a pull request.
 func (c *Client) AddPullRequestComment(ctx context.Context, projectKey, repoSlug string, prID int, text string, opts RequestOpts) error {
 	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/comments",
 		url.PathEscape(projectKey), url.PathEscape(repoSlug), prID)
-	resp, err := c.do(ctx, http.MethodPost, path, AddPRCommentRequest{Text: text}, opts)
+	resp, err := c.do(ctx, http.MethodPost, path, CommentRequest{Text: text}, opts)
 	if err != nil {
 		return err
 	}
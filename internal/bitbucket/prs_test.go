@@ -133,6 +133,40 @@ func TestMergePullRequest(t *testing.T) {
 	}
 }
 
+func TestGetMergeStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"canMerge":false,"conflicted":false,"outcome":"CLEAN","vetoes":[{"summaryMessage":"Not enough approvals","detailedMessage":"Requires 2 approvals"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	status, err := client.GetMergeStatus(context.Background(), "PROJ", "repo", 1, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetMergeStatus: %v", err)
+	}
+	if status.CanMerge || len(status.Vetoes) != 1 || status.Vetoes[0].SummaryMessage != "Not enough approvals" {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestGetMergeStatus_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetMergeStatus(context.Background(), "PROJ", "repo", 1, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func TestMergePullRequest_Error(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
@@ -218,7 +252,7 @@ func TestAddPullRequestComment(t *testing.T) {
 		if r.Method != http.MethodPost {
 			t.Errorf("method = %s", r.Method)
 		}
-		var body AddPRCommentRequest
+		var body CommentRequest
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 			t.Errorf("decode body: %v", err)
 		}
@@ -334,3 +368,68 @@ func TestGetPullRequestParticipants_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestListPullRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != "ALL" || q.Get("filterText") != "feature flag" || q.Get("limit") != "10" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":7,"title":"Add feature flag","state":"MERGED","updatedDate":1700000000000}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListPullRequests(context.Background(), "PROJ", "repo", PullRequestListOptions{State: "ALL", FilterText: "feature flag", Limit: 10}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListPullRequests: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].ID != 7 || resp.Values[0].UpdatedDate != 1700000000000 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListPullRequests_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListPullRequests(context.Background(), "PROJ", "repo", PullRequestListOptions{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListPullRequestCommits(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/5/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("limit = %q", r.URL.Query().Get("limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"a","message":"ABC-1 part one"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListPullRequestCommits(context.Background(), "PROJ", "repo", 5, 0, 100, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListPullRequestCommits: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Message != "ABC-1 part one" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListPullRequestCommits_Error(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, err := client.ListPullRequestCommits(context.Background(), "PROJ", "repo", 5, 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
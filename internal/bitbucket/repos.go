@@ -5,17 +5,36 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 // Repository represents a Bitbucket repository.
 type Repository struct {
-	Slug    string `json:"slug"`
-	Name    string `json:"name"`
-	ID      int    `json:"id"`
-	Project *struct {
-		Key  string `json:"key"`
-		Name string `json:"name"`
-	} `json:"project"`
+	Slug          string      `json:"slug"`
+	Name          string      `json:"name"`
+	ID            int         `json:"id"`
+	Description   string      `json:"description,omitempty"`
+	ScmID         string      `json:"scmId,omitempty"`
+	State         string      `json:"state,omitempty"`
+	StatusMessage string      `json:"statusMessage,omitempty"`
+	Forkable      bool        `json:"forkable"`
+	Public        bool        `json:"public"`
+	Archived      bool        `json:"archived"`
+	Origin        *Repository `json:"origin,omitempty"`
+	Links         *Links      `json:"links,omitempty"`
+	Project       *Project    `json:"project"` // nil when the payload has no project
+}
+
+// Links holds the hypermedia links Bitbucket attaches to an entity.
+type Links struct {
+	Clone []Link `json:"clone,omitempty"`
+	Self  []Link `json:"self,omitempty"`
+}
+
+// Link is a single hypermedia link. Name is set for clone links ("http" or "ssh").
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
 }
 
 // ReposResponse is the paginated API response for listing repos.
@@ -47,3 +66,62 @@ func (c *Client) GetRepository(ctx context.Context, projectKey, repoSlug string,
 	}
 	return &out, nil
 }
+
+// RepositorySize is the on-disk size of a repository in bytes.
+type RepositorySize struct {
+	Repository  int64 `json:"repository"`
+	Attachments int64 `json:"attachments"`
+}
+
+// GetRepositorySize returns the repository size. The endpoint lives outside the
+// REST API (/projects/{key}/repos/{slug}/sizes) and requires repository read access.
+func (c *Client) GetRepositorySize(ctx context.Context, projectKey, repoSlug string, opts RequestOpts) (*RepositorySize, error) {
+	path := "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/sizes"
+	var out RepositorySize
+	if err := c.doJSON(ctx, c.web, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get repository size: %w", err)
+	}
+	return &out, nil
+}
+
+// RepoSearchOptions filters a global repository search (GET /repos).
+type RepoSearchOptions struct {
+	Name        string // case-insensitive substring of the repository name
+	ProjectName string // case-insensitive substring of the project name
+	Permission  string // REPO_READ, REPO_WRITE or REPO_ADMIN
+	Visibility  string // "public" or "private"
+	Start       int
+	Limit       int
+}
+
+// SearchRepositories finds repositories across all projects visible to the caller.
+func (c *Client) SearchRepositories(ctx context.Context, search RepoSearchOptions, opts RequestOpts) (*ReposResponse, error) {
+	q := url.Values{}
+	if search.Name != "" {
+		q.Set("name", search.Name)
+	}
+	if search.ProjectName != "" {
+		q.Set("projectname", search.ProjectName)
+	}
+	if search.Permission != "" {
+		q.Set("permission", search.Permission)
+	}
+	if search.Visibility != "" {
+		q.Set("visibility", search.Visibility)
+	}
+	if search.Start > 0 {
+		q.Set("start", strconv.Itoa(search.Start))
+	}
+	if search.Limit > 0 {
+		q.Set("limit", strconv.Itoa(search.Limit))
+	}
+	path := "/repos"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var out ReposResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("search repositories: %w", err)
+	}
+	return &out, nil
+}
@@ -77,3 +77,123 @@ func TestGetRepository_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestGetRepository_RichFields(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/fork", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"fork","name":"Fork","id":2,"description":"a fork","scmId":"git","state":"AVAILABLE",
+			"forkable":true,"public":false,
+			"origin":{"slug":"upstream","name":"Upstream","id":1,"project":{"key":"UP","name":"Upstream"}},
+			"links":{"clone":[{"href":"https://bb.example.com/scm/proj/fork.git","name":"http"},{"href":"ssh://git@bb.example.com:7999/proj/fork.git","name":"ssh"}],
+			"self":[{"href":"https://bb.example.com/projects/PROJ/repos/fork/browse"}]}}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	repo, err := client.GetRepository(context.Background(), "PROJ", "fork", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetRepository: %v", err)
+	}
+	if repo.Description != "a fork" || repo.State != "AVAILABLE" || !repo.Forkable {
+		t.Errorf("repo = %+v", repo)
+	}
+	if repo.Origin == nil || repo.Origin.Slug != "upstream" || repo.Origin.Project.Key != "UP" {
+		t.Errorf("Origin = %+v", repo.Origin)
+	}
+	if repo.Links == nil || len(repo.Links.Clone) != 2 || repo.Links.Clone[1].Name != "ssh" {
+		t.Errorf("Links = %+v", repo.Links)
+	}
+}
+
+func TestGetRepositorySize(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/PROJ/repos/my-repo/sizes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"repository":2048,"attachments":16}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	size, err := client.GetRepositorySize(context.Background(), "PROJ", "my-repo", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetRepositorySize: %v", err)
+	}
+	if size.Repository != 2048 || size.Attachments != 16 {
+		t.Errorf("size = %+v", size)
+	}
+}
+
+func TestGetRepositorySize_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/PROJ/repos/my-repo/sizes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.GetRepositorySize(context.Background(), "PROJ", "my-repo", RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSearchRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("name") != "api" || q.Get("projectname") != "core" || q.Get("permission") != "REPO_WRITE" ||
+			q.Get("visibility") != "private" || q.Get("start") != "25" || q.Get("limit") != "10" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"slug":"api","name":"API","project":{"key":"CORE","name":"Core"}}],"size":1,"isLastPage":false,"nextPageStart":26}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.SearchRepositories(context.Background(), RepoSearchOptions{
+		Name: "api", ProjectName: "core", Permission: "REPO_WRITE", Visibility: "private", Start: 25, Limit: 10,
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("SearchRepositories: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Project.Key != "CORE" {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+	if resp.IsLastPage || resp.NextPageStart != 26 {
+		t.Errorf("IsLastPage=%v NextPageStart=%d", resp.IsLastPage, resp.NextPageStart)
+	}
+}
+
+func TestSearchRepositories_NoFilters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("query = %q, want empty", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"size":0,"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.SearchRepositories(context.Background(), RepoSearchOptions{}, RequestOpts{}); err != nil {
+		t.Fatalf("SearchRepositories: %v", err)
+	}
+}
+
+func TestSearchRepositories_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"bad permission"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.SearchRepositories(context.Background(), RepoSearchOptions{Permission: "BOGUS"}, RequestOpts{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,146 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Branch restriction types.
+const (
+	RestrictReadOnly        = "read-only"
+	RestrictNoDeletes       = "no-deletes"
+	RestrictFastForwardOnly = "fast-forward-only"
+	RestrictPullRequestOnly = "pull-request-only"
+	RestrictNoCreates       = "no-creates"
+)
+
+// BranchRestriction is a branch permission on a repository or project.
+type BranchRestriction struct {
+	ID         int               `json:"id"`
+	Type       string            `json:"type"`
+	Matcher    RefMatcher        `json:"matcher"`
+	Users      []User            `json:"users"`
+	Groups     []string          `json:"groups"`
+	AccessKeys []any             `json:"accessKeys,omitempty"`
+	Scope      *RestrictionScope `json:"scope,omitempty"`
+}
+
+// RefMatcher selects the refs a restriction or reviewer condition applies to. Type.ID is
+// BRANCH (ID is a ref such as refs/heads/main), PATTERN (e.g. release/*), MODEL_CATEGORY
+// (FEATURE, BUGFIX, HOTFIX, RELEASE), MODEL_BRANCH (production, development) or, for
+// default reviewers only, ANY_REF.
+type RefMatcher struct {
+	ID        string         `json:"id"`
+	DisplayID string         `json:"displayId,omitempty"`
+	Type      RefMatcherType `json:"type"`
+	Active    bool           `json:"active,omitempty"`
+}
+
+// RefMatcherType identifies how a RefMatcher's ID is interpreted.
+type RefMatcherType struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// RestrictionScope is where a restriction is defined: a REPOSITORY or a PROJECT.
+type RestrictionScope struct {
+	Type       string `json:"type"`
+	ResourceID int    `json:"resourceId"`
+}
+
+// BranchRestrictionsResponse is the paged API response for branch restrictions.
+type BranchRestrictionsResponse struct {
+	Values        []BranchRestriction `json:"values"`
+	Size          int                 `json:"size"`
+	Limit         int                 `json:"limit"`
+	IsLastPage    bool                `json:"isLastPage"`
+	Start         int                 `json:"start"`
+	NextPageStart int                 `json:"nextPageStart"`
+}
+
+// CreateRestrictionRequest is the request body for creating a branch restriction.
+// Users and Groups are exempt from the restriction.
+type CreateRestrictionRequest struct {
+	Type    string     `json:"type"`
+	Matcher RefMatcher `json:"matcher"`
+	Users   []string   `json:"users"`
+	Groups  []string   `json:"groups"`
+}
+
+// RestrictionFilter narrows ListBranchRestrictions. Empty fields match everything.
+type RestrictionFilter struct {
+	Type        string
+	MatcherType string
+	MatcherID   string
+}
+
+// restrictionsPath returns the restrictions path of a project, or of a repository when repoSlug is set.
+func restrictionsPath(projectKey, repoSlug string) string {
+	path := "/projects/" + url.PathEscape(projectKey)
+	if repoSlug != "" {
+		path += "/repos/" + url.PathEscape(repoSlug)
+	}
+	return path + "/restrictions"
+}
+
+// anyRefMatcherID is the fixed matcher ID of the ANY_REF matcher type.
+const anyRefMatcherID = "ANY_REF_MATCHER_ID"
+
+// NewRefMatcher builds a matcher of the given type. A BRANCH matcher given a short branch
+// name is qualified to refs/heads/<name>; an ANY_REF matcher ignores id.
+func NewRefMatcher(matcherType, id string) RefMatcher {
+	matcherType = strings.ToUpper(matcherType)
+	switch {
+	case matcherType == "ANY_REF":
+		id = anyRefMatcherID
+	case matcherType == "BRANCH" && !strings.HasPrefix(id, "refs/"):
+		id = "refs/heads/" + id
+	}
+	return RefMatcher{ID: id, Type: RefMatcherType{ID: matcherType}}
+}
+
+// ListBranchRestrictions returns the branch restrictions of a project, or of a repository when repoSlug is set.
+func (c *Client) ListBranchRestrictions(ctx context.Context, projectKey, repoSlug string, filter RestrictionFilter, start int, opts RequestOpts) (*BranchRestrictionsResponse, error) {
+	q := url.Values{}
+	if filter.Type != "" {
+		q.Set("type", filter.Type)
+	}
+	if filter.MatcherType != "" {
+		q.Set("matcherType", strings.ToUpper(filter.MatcherType))
+	}
+	if filter.MatcherID != "" {
+		q.Set("matcherId", filter.MatcherID)
+	}
+	path := restrictionsPath(projectKey, repoSlug) + pagedQuery(q, "", start, 0)
+	var out BranchRestrictionsResponse
+	if err := c.doJSON(ctx, c.restrictions, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list branch restrictions: %w", err)
+	}
+	return &out, nil
+}
+
+// CreateBranchRestriction creates a branch restriction. Requires admin permission on the project or repository.
+func (c *Client) CreateBranchRestriction(ctx context.Context, projectKey, repoSlug string, req CreateRestrictionRequest, opts RequestOpts) (*BranchRestriction, error) {
+	var out BranchRestriction
+	if err := c.doJSON(ctx, c.restrictions, http.MethodPost, restrictionsPath(projectKey, repoSlug), req, &out, opts); err != nil {
+		return nil, fmt.Errorf("create branch restriction: %w", err)
+	}
+	return &out, nil
+}
+
+// DeleteBranchRestriction deletes a branch restriction by ID.
+func (c *Client) DeleteBranchRestriction(ctx context.Context, projectKey, repoSlug string, id int, opts RequestOpts) error {
+	path := restrictionsPath(projectKey, repoSlug) + "/" + strconv.Itoa(id)
+	resp, err := c.doClient(ctx, c.restrictions, http.MethodDelete, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("delete branch restriction failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const restrictionJSON = `{"id":3,"type":"pull-request-only","scope":{"type":"REPOSITORY","resourceId":1},
+	"matcher":{"id":"refs/heads/main","displayId":"main","type":{"id":"BRANCH","name":"Branch"},"active":true},
+	"users":[{"name":"release-bot"}],"groups":["admins"],"accessKeys":[]}`
+
+func TestRestrictionsPath(t *testing.T) {
+	if got := restrictionsPath("PROJ", ""); got != "/projects/PROJ/restrictions" {
+		t.Errorf("project path = %q", got)
+	}
+	if got := restrictionsPath("PROJ", "repo"); got != "/projects/PROJ/repos/repo/restrictions" {
+		t.Errorf("repo path = %q", got)
+	}
+}
+
+func TestNewRefMatcher(t *testing.T) {
+	tests := []struct {
+		typ, id, wantType, wantID string
+	}{
+		{"branch", "main", "BRANCH", "refs/heads/main"},
+		{"BRANCH", "refs/heads/main", "BRANCH", "refs/heads/main"},
+		{"PATTERN", "release/*", "PATTERN", "release/*"},
+		{"model_category", "HOTFIX", "MODEL_CATEGORY", "HOTFIX"},
+	}
+	for _, tt := range tests {
+		m := NewRefMatcher(tt.typ, tt.id)
+		if m.Type.ID != tt.wantType || m.ID != tt.wantID {
+			t.Errorf("NewRefMatcher(%q, %q) = %+v", tt.typ, tt.id, m)
+		}
+	}
+}
+
+func TestListBranchRestrictions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/repos/repo/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("type") != "pull-request-only" || q.Get("matcherType") != "BRANCH" || q.Get("matcherId") != "refs/heads/main" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[` + restrictionJSON + `],"size":1,"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListBranchRestrictions(context.Background(), "PROJ", "repo", RestrictionFilter{
+		Type: RestrictPullRequestOnly, MatcherType: "branch", MatcherID: "refs/heads/main",
+	}, 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListBranchRestrictions: %v", err)
+	}
+	r := resp.Values[0]
+	if r.ID != 3 || r.Matcher.DisplayID != "main" || r.Users[0].Name != "release-bot" || r.Groups[0] != "admins" || r.Scope.Type != "REPOSITORY" {
+		t.Errorf("restriction = %+v", r)
+	}
+}
+
+func TestListBranchRestrictions_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListBranchRestrictions(context.Background(), "PROJ", "", RestrictionFilter{}, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateBranchRestriction(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/repos/repo/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		matcher := body["matcher"].(map[string]any)
+		if body["type"] != "pull-request-only" || matcher["id"] != "refs/heads/main" || matcher["type"].(map[string]any)["id"] != "BRANCH" {
+			t.Errorf("body = %v", body)
+		}
+		if users := body["users"].([]any); len(users) != 1 || users[0] != "release-bot" {
+			t.Errorf("users = %v", body["users"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(restrictionJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	r, err := client.CreateBranchRestriction(context.Background(), "PROJ", "repo", CreateRestrictionRequest{
+		Type: RestrictPullRequestOnly, Matcher: NewRefMatcher("BRANCH", "main"),
+		Users: []string{"release-bot"}, Groups: []string{"admins"},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("CreateBranchRestriction: %v", err)
+	}
+	if r.ID != 3 {
+		t.Errorf("ID = %d", r.ID)
+	}
+}
+
+func TestCreateBranchRestriction_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.CreateBranchRestriction(context.Background(), "PROJ", "", CreateRestrictionRequest{Type: "bogus"}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteBranchRestriction(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/repos/repo/restrictions/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteBranchRestriction(context.Background(), "PROJ", "repo", 3, RequestOpts{}); err != nil {
+		t.Fatalf("DeleteBranchRestriction: %v", err)
+	}
+}
+
+func TestDeleteBranchRestriction_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/repos/repo/restrictions/3", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteBranchRestriction(context.Background(), "PROJ", "repo", 3, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteBranchRestriction_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.DeleteBranchRestriction(context.Background(), "PROJ", "repo", 3, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ReviewerCondition adds default reviewers to pull requests whose source and target
+// refs match its matchers.
+type ReviewerCondition struct {
+	ID                int               `json:"id"`
+	Scope             *RestrictionScope `json:"scope,omitempty"`
+	SourceRefMatcher  RefMatcher        `json:"sourceRefMatcher"`
+	TargetRefMatcher  RefMatcher        `json:"targetRefMatcher"`
+	Reviewers         []User            `json:"reviewers"`
+	RequiredApprovals int               `json:"requiredApprovals"`
+}
+
+// CreateReviewerConditionRequest is the request body for creating a default reviewer condition.
+type CreateReviewerConditionRequest struct {
+	SourceMatcher     RefMatcher    `json:"sourceMatcher"`
+	TargetMatcher     RefMatcher    `json:"targetMatcher"`
+	Reviewers         []ReviewerRef `json:"reviewers"`
+	RequiredApprovals int           `json:"requiredApprovals"`
+}
+
+// ReviewerRef identifies a reviewer by user ID.
+type ReviewerRef struct {
+	ID int `json:"id"`
+}
+
+// ReviewerPair identifies the source and target of a prospective pull request.
+type ReviewerPair struct {
+	SourceRepoID int
+	SourceRefID  string
+	TargetRepoID int
+	TargetRefID  string
+}
+
+// conditionsPath returns the default reviewer path of a project, or of a repository when
+// repoSlug is set, with the given suffix (conditions, condition or reviewers).
+func conditionsPath(projectKey, repoSlug, suffix string) string {
+	path := "/projects/" + url.PathEscape(projectKey)
+	if repoSlug != "" {
+		path += "/repos/" + url.PathEscape(repoSlug)
+	}
+	return path + "/" + suffix
+}
+
+// ListReviewerConditions returns the default reviewer conditions of a project, or of a
+// repository (including those inherited from its project) when repoSlug is set.
+func (c *Client) ListReviewerConditions(ctx context.Context, projectKey, repoSlug string, opts RequestOpts) ([]ReviewerCondition, error) {
+	var out []ReviewerCondition
+	if err := c.doJSON(ctx, c.reviewers, http.MethodGet, conditionsPath(projectKey, repoSlug, "conditions"), nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list reviewer conditions: %w", err)
+	}
+	return out, nil
+}
+
+// CreateReviewerCondition creates a default reviewer condition. Requires admin permission.
+func (c *Client) CreateReviewerCondition(ctx context.Context, projectKey, repoSlug string, req CreateReviewerConditionRequest, opts RequestOpts) (*ReviewerCondition, error) {
+	var out ReviewerCondition
+	if err := c.doJSON(ctx, c.reviewers, http.MethodPost, conditionsPath(projectKey, repoSlug, "condition"), req, &out, opts); err != nil {
+		return nil, fmt.Errorf("create reviewer condition: %w", err)
+	}
+	return &out, nil
+}
+
+// DeleteReviewerCondition deletes a default reviewer condition by ID.
+func (c *Client) DeleteReviewerCondition(ctx context.Context, projectKey, repoSlug string, id int, opts RequestOpts) error {
+	path := conditionsPath(projectKey, repoSlug, "condition") + "/" + strconv.Itoa(id)
+	resp, err := c.doClient(ctx, c.reviewers, http.MethodDelete, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("delete reviewer condition failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
+
+// GetEffectiveReviewers returns the default reviewers Bitbucket would add to a pull request
+// from pair's source to its target in the given repository.
+func (c *Client) GetEffectiveReviewers(ctx context.Context, projectKey, repoSlug string, pair ReviewerPair, opts RequestOpts) ([]User, error) {
+	q := url.Values{}
+	q.Set("sourceRepoId", strconv.Itoa(pair.SourceRepoID))
+	q.Set("sourceRefId", pair.SourceRefID)
+	q.Set("targetRepoId", strconv.Itoa(pair.TargetRepoID))
+	q.Set("targetRefId", pair.TargetRefID)
+	path := conditionsPath(projectKey, repoSlug, "reviewers") + "?" + q.Encode()
+	var out []User
+	if err := c.doJSON(ctx, c.reviewers, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get effective reviewers: %w", err)
+	}
+	return out, nil
+}
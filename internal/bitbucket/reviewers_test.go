@@ -0,0 +1,182 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const conditionJSON = `{"id":4,"scope":{"type":"REPOSITORY","resourceId":1},
+	"sourceRefMatcher":{"id":"ANY_REF_MATCHER_ID","type":{"id":"ANY_REF"}},
+	"targetRefMatcher":{"id":"refs/heads/main","displayId":"main","type":{"id":"BRANCH"}},
+	"reviewers":[{"name":"alice","id":11}],"requiredApprovals":1}`
+
+func TestConditionsPath(t *testing.T) {
+	if got := conditionsPath("PROJ", "", "conditions"); got != "/projects/PROJ/conditions" {
+		t.Errorf("project path = %q", got)
+	}
+	if got := conditionsPath("PROJ", "repo", "condition"); got != "/projects/PROJ/repos/repo/condition" {
+		t.Errorf("repo path = %q", got)
+	}
+}
+
+func TestNewRefMatcher_AnyRef(t *testing.T) {
+	m := NewRefMatcher("any_ref", "ignored")
+	if m.ID != "ANY_REF_MATCHER_ID" || m.Type.ID != "ANY_REF" {
+		t.Errorf("matcher = %+v", m)
+	}
+}
+
+func TestListReviewerConditions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/conditions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[` + conditionJSON + `]`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	conditions, err := client.ListReviewerConditions(context.Background(), "PROJ", "repo", RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListReviewerConditions: %v", err)
+	}
+	c := conditions[0]
+	if c.ID != 4 || c.SourceRefMatcher.Type.ID != "ANY_REF" || c.TargetRefMatcher.DisplayID != "main" || c.Reviewers[0].ID != 11 || c.RequiredApprovals != 1 {
+		t.Errorf("condition = %+v", c)
+	}
+}
+
+func TestListReviewerConditions_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/conditions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListReviewerConditions(context.Background(), "PROJ", "", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateReviewerCondition(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/condition", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body CreateReviewerConditionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.SourceMatcher.ID != "ANY_REF_MATCHER_ID" || body.TargetMatcher.ID != "refs/heads/main" || body.Reviewers[0].ID != 11 || body.RequiredApprovals != 1 {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(conditionJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	c, err := client.CreateReviewerCondition(context.Background(), "PROJ", "repo", CreateReviewerConditionRequest{
+		SourceMatcher:     NewRefMatcher("ANY_REF", ""),
+		TargetMatcher:     NewRefMatcher("BRANCH", "main"),
+		Reviewers:         []ReviewerRef{{ID: 11}},
+		RequiredApprovals: 1,
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("CreateReviewerCondition: %v", err)
+	}
+	if c.ID != 4 {
+		t.Errorf("ID = %d", c.ID)
+	}
+}
+
+func TestCreateReviewerCondition_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/condition", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.CreateReviewerCondition(context.Background(), "PROJ", "", CreateReviewerConditionRequest{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteReviewerCondition(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/condition/4", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteReviewerCondition(context.Background(), "PROJ", "repo", 4, RequestOpts{}); err != nil {
+		t.Fatalf("DeleteReviewerCondition: %v", err)
+	}
+}
+
+func TestDeleteReviewerCondition_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/condition/4", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteReviewerCondition(context.Background(), "PROJ", "repo", 4, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteReviewerCondition_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.DeleteReviewerCondition(context.Background(), "PROJ", "repo", 4, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetEffectiveReviewers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sourceRepoId") != "2" || q.Get("targetRepoId") != "1" || q.Get("sourceRefId") != "refs/heads/feature" || q.Get("targetRefId") != "refs/heads/main" {
+			t.Errorf("query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"alice","id":11},{"name":"bob","id":12}]`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	users, err := client.GetEffectiveReviewers(context.Background(), "PROJ", "repo", ReviewerPair{
+		SourceRepoID: 2, SourceRefID: "refs/heads/feature", TargetRepoID: 1, TargetRefID: "refs/heads/main",
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetEffectiveReviewers: %v", err)
+	}
+	if len(users) != 2 || users[1].Name != "bob" {
+		t.Errorf("users = %+v", users)
+	}
+}
+
+func TestGetEffectiveReviewers_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/repos/repo/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetEffectiveReviewers(context.Background(), "PROJ", "repo", ReviewerPair{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
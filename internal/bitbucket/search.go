@@ -3,46 +3,182 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
+	"strings"
 )
 
-// SearchResult represents a code search result (Bitbucket Data Center 8+).
+// ErrSearchUnavailable is returned by SearchContent when the server has no code search
+// (Bitbucket Data Center before 8, or search disabled). GrepContent can stand in for it.
+var ErrSearchUnavailable = errors.New("code search is not available on this Bitbucket server (requires Data Center 8+ with search enabled)")
+
+// SearchResult is one file matching a code search (Bitbucket Data Center 8+).
 type SearchResult struct {
-	Path    string `json:"path"`
-	Content string `json:"content"`
+	Repository *Repository `json:"repository"`
+	File       string      `json:"file"`
+	// HitContexts holds one group of consecutive lines per hit. Matches in Text are
+	// wrapped in <em> tags and the text is HTML-escaped.
+	HitContexts [][]SearchHitLine `json:"hitContexts"`
+	// PathMatches are the ranges of File that matched the query.
+	PathMatches []SearchRange `json:"pathMatches"`
+	HitCount    int           `json:"hitCount"`
+}
+
+// SearchHitLine is a line of a search hit context.
+type SearchHitLine struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
 }
 
-// SearchResponse is the API response for code search.
+// SearchRange is a matched range of characters [Start, End).
+type SearchRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SearchResponse is a page of code search results.
 type SearchResponse struct {
-	Values []SearchResult `json:"values"`
+	Count      int            `json:"count"`
+	Start      int            `json:"start"`
+	NextStart  int            `json:"nextStart,omitempty"`
+	IsLastPage bool           `json:"isLastPage"`
+	Values     []SearchResult `json:"values"`
+}
+
+// searchRequest is the request body of POST /search.
+type searchRequest struct {
+	Query    string `json:"query"`
+	Entities struct {
+		Code searchPage `json:"code"`
+	} `json:"entities"`
+	Limits struct {
+		Primary   int `json:"primary"`
+		Secondary int `json:"secondary"`
+	} `json:"limits"`
+}
+
+type searchPage struct {
+	Start int `json:"start"`
+	Limit int `json:"limit"`
+}
+
+// searchResults is the response of POST /search. Bitbucket returns the code results at
+// the top level; some versions nest them under entities.
+type searchResults struct {
+	Code     *SearchResponse `json:"code"`
+	Entities struct {
+		Code *SearchResponse `json:"code"`
+	} `json:"entities"`
+}
+
+// defaultSearchLimit is the page size requested from the search API.
+const defaultSearchLimit = 25
+
+// CodeSearchOptions describes a code search. The structured fields are turned into
+// Bitbucket search query modifiers and combined with Query.
+type CodeSearchOptions struct {
+	Query      string   // free text; may itself use AND, OR, NOT and quotes
+	AnyOf      []string // at least one of these terms must match (OR)
+	Exclude    []string // none of these terms may match (NOT)
+	Project    string   // project: modifier
+	Repository string   // repo: modifier; combine with Project to disambiguate
+	Language   string   // lang: modifier, e.g. java or go
+	Path       string   // path: modifier, e.g. src/main
+	Extension  string   // ext: modifier, with or without the leading dot
+	Start      int
+	Limit      int // primary (code) page size; default 25
+	// SecondaryLimit caps the secondary results (repositories and projects) that
+	// Bitbucket returns alongside code hits.
+	SecondaryLimit int
 }
 
-// SearchContent searches for code in repositories.
+// searchQuery builds the Bitbucket search query string for search.
+func searchQuery(search CodeSearchOptions) string {
+	var terms []string
+	if search.Query != "" {
+		terms = append(terms, search.Query)
+	}
+	if len(search.AnyOf) > 0 {
+		quoted := make([]string, len(search.AnyOf))
+		for i, t := range search.AnyOf {
+			quoted[i] = searchTerm(t)
+		}
+		alternatives := strings.Join(quoted, " OR ")
+		if len(quoted) > 1 && len(terms) > 0 {
+			alternatives = "(" + alternatives + ")"
+		}
+		terms = append(terms, alternatives)
+	}
+	for _, t := range search.Exclude {
+		terms = append(terms, "NOT "+searchTerm(t))
+	}
+	for _, m := range []struct{ key, value string }{
+		{"project", search.Project},
+		{"repo", search.Repository},
+		{"lang", search.Language},
+		{"path", search.Path},
+		{"ext", strings.TrimPrefix(search.Extension, ".")},
+	} {
+		if m.value != "" {
+			terms = append(terms, m.key+":"+searchTerm(m.value))
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchTerm quotes t when it contains spaces so it is searched as a phrase.
+func searchTerm(t string) string {
+	if strings.ContainsAny(t, " \t") && !strings.HasPrefix(t, `"`) {
+		return `"` + strings.ReplaceAll(t, `"`, `\"`) + `"`
+	}
+	return t
+}
+
+// SearchContent searches for code in repositories. Page through results with Start set
+// to the previous response's NextStart until IsLastPage.
 // Note: Code search requires Bitbucket Data Center 8+ with search enabled.
-// For older versions, returns empty results.
-func (c *Client) SearchContent(ctx context.Context, workspaceSlug, query, extension string, opts RequestOpts) (*SearchResponse, error) {
-	apiPath := "/search?searchQuery=" + url.QueryEscape(query)
-	if workspaceSlug != "" {
-		apiPath += "&projectKey=" + url.QueryEscape(workspaceSlug)
+// For older versions, returns ErrSearchUnavailable.
+func (c *Client) SearchContent(ctx context.Context, search CodeSearchOptions, opts RequestOpts) (*SearchResponse, error) {
+	limit := search.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
 	}
-	if extension != "" {
-		apiPath += "&fileExtension=" + url.QueryEscape(extension)
+	secondary := search.SecondaryLimit
+	if secondary <= 0 {
+		secondary = 10
 	}
-	resp, err := c.doSearch(ctx, http.MethodGet, apiPath, nil, opts)
+	var body searchRequest
+	body.Query = searchQuery(search)
+	body.Entities.Code = searchPage{Start: search.Start, Limit: limit}
+	body.Limits.Primary, body.Limits.Secondary = limit, secondary
+	if body.Query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+
+	resp, err := c.doSearch(ctx, http.MethodPost, "/search", body, opts)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode() == 404 || resp.StatusCode() == 501 {
-		return &SearchResponse{Values: []SearchResult{}}, nil
+		return nil, ErrSearchUnavailable
 	}
 	if resp.IsError() {
 		return nil, fmt.Errorf("search failed %d: %s (code search requires Bitbucket Data Center 8+)", resp.StatusCode(), resp.String())
 	}
-	var out SearchResponse
+	var out searchResults
 	if err := json.Unmarshal(resp.Body(), &out); err != nil {
 		return nil, fmt.Errorf("search decode: %w", err)
 	}
-	return &out, nil
+	code := out.Code
+	if code == nil {
+		code = out.Entities.Code
+	}
+	if code == nil {
+		code = &SearchResponse{IsLastPage: true}
+	}
+	if code.Values == nil {
+		code.Values = []SearchResult{}
+	}
+	return code, nil
 }
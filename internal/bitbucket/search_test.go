@@ -2,76 +2,106 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 )
 
 func TestSearchContent(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
-		q := r.URL.Query()
-		if q.Get("searchQuery") != "hello" {
-			t.Errorf("searchQuery = %q", q.Get("searchQuery"))
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
 		}
-		if q.Get("projectKey") != "PROJ" {
-			t.Errorf("projectKey = %q", q.Get("projectKey"))
+		var body searchRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
 		}
-		if q.Get("fileExtension") != "go" {
-			t.Errorf("fileExtension = %q", q.Get("fileExtension"))
+		if body.Query != "hello project:PROJ ext:go" {
+			t.Errorf("query = %q", body.Query)
+		}
+		if body.Entities.Code.Limit != defaultSearchLimit {
+			t.Errorf("entities.code.limit = %d", body.Entities.Code.Limit)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"values":[{"path":"main.go","content":"hello world"}]}`))
+		_, _ = w.Write([]byte(`{"scope":{"type":"GLOBAL"},"code":{"category":"primary","isLastPage":false,"count":30,"start":0,"nextStart":25,
+			"values":[{"repository":{"slug":"repo","name":"repo","project":{"key":"PROJ"}},"file":"cmd/main.go",
+			"hitContexts":[[{"line":9,"text":"func main() {"},{"line":10,"text":"\tfmt.Println(&quot;<em>hello</em>&quot;)"}]],
+			"pathMatches":[],"hitCount":1}]},"query":{"substituted":false}}`))
 	})
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	resp, err := client.SearchContent(context.Background(), "PROJ", "hello", "go", RequestOpts{})
+	resp, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "hello", Project: "PROJ", Extension: ".go"}, RequestOpts{})
 	if err != nil {
 		t.Fatalf("SearchContent: %v", err)
 	}
-	if len(resp.Values) != 1 {
-		t.Fatalf("got %d results", len(resp.Values))
+	if resp.Count != 30 || resp.NextStart != 25 || resp.IsLastPage || len(resp.Values) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	hit := resp.Values[0]
+	if hit.File != "cmd/main.go" || hit.Repository.Slug != "repo" || hit.Repository.Project.Key != "PROJ" {
+		t.Errorf("hit = %+v", hit)
 	}
-	if resp.Values[0].Path != "main.go" {
-		t.Errorf("Path = %q", resp.Values[0].Path)
+	if len(hit.HitContexts) != 1 || hit.HitContexts[0][1].Line != 10 || !strings.Contains(hit.HitContexts[0][1].Text, "<em>hello</em>") {
+		t.Errorf("hitContexts = %+v", hit.HitContexts)
 	}
 }
 
-func TestSearchContent_NoWorkspace(t *testing.T) {
+func TestSearchContent_EntitiesEnvelope(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Query().Get("projectKey") != "" {
-			t.Errorf("projectKey should be empty, got %q", r.URL.Query().Get("projectKey"))
+		var body searchRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Query != "test" {
+			t.Errorf("query = %q, want no modifiers", body.Query)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"values":[]}`))
+		_, _ = w.Write([]byte(`{"entities":{"code":{"count":1,"isLastPage":true,"values":[{"file":"a.txt","hitCount":1}]}}}`))
 	})
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	resp, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
+	resp, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
 	if err != nil {
 		t.Fatalf("SearchContent: %v", err)
 	}
-	if len(resp.Values) != 0 {
-		t.Errorf("expected 0 results, got %d", len(resp.Values))
+	if len(resp.Values) != 1 || resp.Values[0].File != "a.txt" {
+		t.Errorf("resp = %+v", resp)
 	}
 }
 
-func TestSearchContent_NotSupported(t *testing.T) {
+func TestSearchContent_NoCodeResults(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(404)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"query":"test","entities":{"code":{"count":0,"limit":25}}}`))
 	})
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	resp, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
+	resp, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
 	if err != nil {
-		t.Fatalf("SearchContent 404 should not error: %v", err)
+		t.Fatalf("SearchContent: %v", err)
 	}
-	if len(resp.Values) != 0 {
-		t.Errorf("expected empty results for 404")
+	if resp.Values == nil || len(resp.Values) != 0 {
+		t.Errorf("expected empty non-nil results, got %+v", resp.Values)
+	}
+}
+
+func TestSearchContent_NotSupported(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	_, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
+	if !errors.Is(err, ErrSearchUnavailable) {
+		t.Fatalf("SearchContent 404: err = %v, want ErrSearchUnavailable", err)
 	}
 }
 
@@ -83,12 +113,9 @@ func TestSearchContent_501NotImplemented(t *testing.T) {
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	resp, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
-	if err != nil {
-		t.Fatalf("SearchContent 501 should not error: %v", err)
-	}
-	if len(resp.Values) != 0 {
-		t.Errorf("expected empty results for 501")
+	_, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
+	if !errors.Is(err, ErrSearchUnavailable) {
+		t.Fatalf("SearchContent 501: err = %v, want ErrSearchUnavailable", err)
 	}
 }
 
@@ -101,7 +128,7 @@ func TestSearchContent_ServerError(t *testing.T) {
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	_, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
+	_, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
 	if err == nil {
 		t.Fatal("expected error for 500")
 	}
@@ -112,7 +139,7 @@ func TestSearchContent_TransportError(t *testing.T) {
 	client, ts := newTestServer(mux)
 	ts.Close()
 
-	_, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
+	_, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
 	if err == nil {
 		t.Fatal("expected error for closed server")
 	}
@@ -127,8 +154,66 @@ func TestSearchContent_InvalidJSON(t *testing.T) {
 	client, ts := newTestServer(mux)
 	defer ts.Close()
 
-	_, err := client.SearchContent(context.Background(), "", "test", "", RequestOpts{})
+	_, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "test"}, RequestOpts{})
 	if err == nil {
 		t.Fatal("expected error for invalid JSON")
 	}
 }
+
+func TestSearchContent_Paging(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
+		var body searchRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Entities.Code.Start != 50 || body.Entities.Code.Limit != 50 || body.Limits.Primary != 50 || body.Limits.Secondary != 5 {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":{"count":120,"start":50,"nextStart":100,"isLastPage":false,"values":[]}}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.SearchContent(context.Background(), CodeSearchOptions{Query: "x", Start: 50, Limit: 50, SecondaryLimit: 5}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("SearchContent: %v", err)
+	}
+	if resp.IsLastPage || resp.NextStart != 100 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestSearchContent_EmptyQuery(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	defer ts.Close()
+
+	// A modifier-only query is sent; the empty mux answers 404.
+	if _, err := client.SearchContent(context.Background(), CodeSearchOptions{Extension: "go"}, RequestOpts{}); !errors.Is(err, ErrSearchUnavailable) {
+		t.Errorf("modifier-only search: %v", err)
+	}
+	if _, err := client.SearchContent(context.Background(), CodeSearchOptions{}, RequestOpts{}); err == nil {
+		t.Error("expected error for empty query")
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		search CodeSearchOptions
+		want   string
+	}{
+		{"text only", CodeSearchOptions{Query: "foo AND bar"}, "foo AND bar"},
+		{"modifiers", CodeSearchOptions{Query: "Config", Project: "PROJ", Repository: "api", Language: "java", Path: "src/main", Extension: ".java"},
+			"Config project:PROJ repo:api lang:java path:src/main ext:java"},
+		{"any of", CodeSearchOptions{Query: "client", AnyOf: []string{"retry", "backoff policy"}},
+			`client (retry OR "backoff policy")`},
+		{"any of alone", CodeSearchOptions{AnyOf: []string{"a", "b"}}, "a OR b"},
+		{"exclude", CodeSearchOptions{Query: "token", Exclude: []string{"test", "mock"}}, "token NOT test NOT mock"},
+		{"quoted path", CodeSearchOptions{Query: "x", Path: "my docs"}, `x path:"my docs"`},
+	}
+	for _, tt := range tests {
+		if got := searchQuery(tt.search); got != tt.want {
+			t.Errorf("%s: searchQuery = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PullRequestSettings are the repository settings that gate merging. On update, nil
+// fields are left unchanged.
+type PullRequestSettings struct {
+	RequiredApprovers        *int         `json:"requiredApprovers,omitempty"`
+	RequiredAllApprovers     *bool        `json:"requiredAllApprovers,omitempty"`
+	RequiredAllTasksComplete *bool        `json:"requiredAllTasksComplete,omitempty"`
+	RequiredSuccessfulBuilds *int         `json:"requiredSuccessfulBuilds,omitempty"`
+	MergeConfig              *MergeConfig `json:"mergeConfig,omitempty"`
+}
+
+// MergeConfig lists the merge strategies a repository allows and which one is the default.
+type MergeConfig struct {
+	DefaultStrategy *MergeStrategy  `json:"defaultStrategy,omitempty"`
+	Strategies      []MergeStrategy `json:"strategies,omitempty"`
+	Type            string          `json:"type,omitempty"` // DEFAULT, PROJECT or REPOSITORY: where the config is inherited from
+}
+
+// MergeStrategy is a merge strategy such as no-ff, ff-only, squash or rebase-no-ff.
+type MergeStrategy struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled,omitempty"`
+	Flag        string `json:"flag,omitempty"`
+}
+
+// RepositoryHook is a pre-receive, post-receive or merge check hook and its state.
+type RepositoryHook struct {
+	Details    HookDetails       `json:"details"`
+	Enabled    bool              `json:"enabled"`
+	Configured bool              `json:"configured"`
+	Scope      *RestrictionScope `json:"scope,omitempty"`
+}
+
+// HookDetails describes a hook. Type is PRE_RECEIVE, POST_RECEIVE or PRE_PULL_REQUEST_MERGE.
+type HookDetails struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// HooksResponse is the paged API response for hooks.
+type HooksResponse struct {
+	Values        []RepositoryHook `json:"values"`
+	Size          int              `json:"size"`
+	Limit         int              `json:"limit"`
+	IsLastPage    bool             `json:"isLastPage"`
+	Start         int              `json:"start"`
+	NextPageStart int              `json:"nextPageStart"`
+}
+
+func pullRequestSettingsPath(projectKey, repoSlug string) string {
+	return "/projects/" + url.PathEscape(projectKey) + "/repos/" + url.PathEscape(repoSlug) + "/settings/pull-requests"
+}
+
+// hooksPath returns the hooks path of a project, or of a repository when repoSlug is set.
+func hooksPath(projectKey, repoSlug string) string {
+	path := "/projects/" + url.PathEscape(projectKey)
+	if repoSlug != "" {
+		path += "/repos/" + url.PathEscape(repoSlug)
+	}
+	return path + "/settings/hooks"
+}
+
+// GetPullRequestSettings returns a repository's merge checks and merge strategies.
+func (c *Client) GetPullRequestSettings(ctx context.Context, projectKey, repoSlug string, opts RequestOpts) (*PullRequestSettings, error) {
+	var out PullRequestSettings
+	if err := c.doJSON(ctx, c.api, http.MethodGet, pullRequestSettingsPath(projectKey, repoSlug), nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get pull request settings: %w", err)
+	}
+	return &out, nil
+}
+
+// UpdatePullRequestSettings updates a repository's merge checks and merge strategies.
+// Requires REPO_ADMIN permission.
+func (c *Client) UpdatePullRequestSettings(ctx context.Context, projectKey, repoSlug string, settings PullRequestSettings, opts RequestOpts) (*PullRequestSettings, error) {
+	var out PullRequestSettings
+	if err := c.doJSON(ctx, c.api, http.MethodPost, pullRequestSettingsPath(projectKey, repoSlug), settings, &out, opts); err != nil {
+		return nil, fmt.Errorf("update pull request settings: %w", err)
+	}
+	return &out, nil
+}
+
+// ListHooks returns the hooks of a project, or of a repository when repoSlug is set.
+// A non-empty hookType limits the result to that type.
+func (c *Client) ListHooks(ctx context.Context, projectKey, repoSlug, hookType string, start int, opts RequestOpts) (*HooksResponse, error) {
+	q := url.Values{}
+	if hookType != "" {
+		q.Set("type", hookType)
+	}
+	path := hooksPath(projectKey, repoSlug) + pagedQuery(q, "", start, 0)
+	var out HooksResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list hooks: %w", err)
+	}
+	return &out, nil
+}
+
+// SetHookEnabled enables or disables a hook on a project, or on a repository when repoSlug is set.
+func (c *Client) SetHookEnabled(ctx context.Context, projectKey, repoSlug, hookKey string, enabled bool, opts RequestOpts) (*RepositoryHook, error) {
+	path := hooksPath(projectKey, repoSlug) + "/" + url.PathEscape(hookKey) + "/enabled"
+	method := http.MethodPut
+	if !enabled {
+		method = http.MethodDelete
+	}
+	var out RepositoryHook
+	if err := c.doJSON(ctx, c.api, method, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("set hook enabled: %w", err)
+	}
+	return &out, nil
+}
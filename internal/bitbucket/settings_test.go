@@ -0,0 +1,166 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const prSettingsPath = "/rest/api/1.0/projects/PROJ/repos/repo/settings/pull-requests"
+
+const prSettingsJSON = `{"requiredApprovers":2,"requiredAllApprovers":false,"requiredAllTasksComplete":true,"requiredSuccessfulBuilds":1,
+	"mergeConfig":{"defaultStrategy":{"id":"no-ff","name":"Merge commit","enabled":true},
+		"strategies":[{"id":"no-ff","enabled":true},{"id":"squash","enabled":true},{"id":"ff-only","enabled":false}],"type":"REPOSITORY"}}`
+
+func TestHooksPath(t *testing.T) {
+	if got := hooksPath("PROJ", ""); got != "/projects/PROJ/settings/hooks" {
+		t.Errorf("project path = %q", got)
+	}
+	if got := hooksPath("PROJ", "repo"); got != "/projects/PROJ/repos/repo/settings/hooks" {
+		t.Errorf("repo path = %q", got)
+	}
+}
+
+func TestGetPullRequestSettings(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(prSettingsJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	settings, err := client.GetPullRequestSettings(context.Background(), "PROJ", "repo", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetPullRequestSettings: %v", err)
+	}
+	if *settings.RequiredApprovers != 2 || !*settings.RequiredAllTasksComplete || *settings.RequiredSuccessfulBuilds != 1 {
+		t.Errorf("settings = %+v", settings)
+	}
+	if settings.MergeConfig.DefaultStrategy.ID != "no-ff" || len(settings.MergeConfig.Strategies) != 3 {
+		t.Errorf("mergeConfig = %+v", settings.MergeConfig)
+	}
+}
+
+func TestGetPullRequestSettings_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetPullRequestSettings(context.Background(), "PROJ", "repo", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUpdatePullRequestSettings(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if len(body) != 1 || body["requiredApprovers"] != float64(2) {
+			t.Errorf("body = %v, want only requiredApprovers", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(prSettingsJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	two := 2
+	if _, err := client.UpdatePullRequestSettings(context.Background(), "PROJ", "repo", PullRequestSettings{RequiredApprovers: &two}, RequestOpts{}); err != nil {
+		t.Fatalf("UpdatePullRequestSettings: %v", err)
+	}
+}
+
+func TestUpdatePullRequestSettings_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.UpdatePullRequestSettings(context.Background(), "PROJ", "repo", PullRequestSettings{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListHooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/settings/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "PRE_RECEIVE" {
+			t.Errorf("type = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"details":{"key":"com.example:force-push","name":"Reject Force Push","type":"PRE_RECEIVE"},"enabled":true,"configured":true}],"size":1,"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListHooks(context.Background(), "PROJ", "repo", "PRE_RECEIVE", 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListHooks: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Details.Key != "com.example:force-push" || !resp.Values[0].Enabled {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListHooks_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/settings/hooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListHooks(context.Background(), "PROJ", "", "", 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSetHookEnabled(t *testing.T) {
+	var methods []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/settings/hooks/com.example:force-push/enabled", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"details":{"key":"com.example:force-push"},"enabled":` + map[string]string{http.MethodPut: "true", http.MethodDelete: "false"}[r.Method] + `}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	hook, err := client.SetHookEnabled(context.Background(), "PROJ", "repo", "com.example:force-push", true, RequestOpts{})
+	if err != nil || !hook.Enabled {
+		t.Fatalf("enable: hook = %+v, err = %v", hook, err)
+	}
+	hook, err = client.SetHookEnabled(context.Background(), "PROJ", "repo", "com.example:force-push", false, RequestOpts{})
+	if err != nil || hook.Enabled {
+		t.Fatalf("disable: hook = %+v, err = %v", hook, err)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodPut || methods[1] != http.MethodDelete {
+		t.Errorf("methods = %v", methods)
+	}
+}
+
+func TestSetHookEnabled_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/settings/hooks/missing/enabled", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.SetHookEnabled(context.Background(), "PROJ", "", "missing", true, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
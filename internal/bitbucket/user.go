@@ -2,10 +2,16 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
+// ErrUnauthorized is returned when Bitbucket rejects the request's token (401).
+var ErrUnauthorized = errors.New("bitbucket rejected the token")
+
 // User represents a Bitbucket user.
 type User struct {
 	Name         string `json:"name"`
@@ -13,13 +19,105 @@ type User struct {
 	DisplayName  string `json:"displayName"`
 	ID           int    `json:"id"`
 	Active       bool   `json:"active"`
+	Slug         string `json:"slug,omitempty"`
+	Type         string `json:"type,omitempty"` // NORMAL or SERVICE
+}
+
+// UsersResponse is the paginated API response for listing users.
+type UsersResponse struct {
+	Values        []User `json:"values"`
+	Size          int    `json:"size"`
+	Limit         int    `json:"limit"`
+	IsLastPage    bool   `json:"isLastPage"`
+	Start         int    `json:"start"`
+	NextPageStart int    `json:"nextPageStart"`
+}
+
+// GroupNamesResponse is the paginated API response for listing group names.
+type GroupNamesResponse struct {
+	Values        []string `json:"values"`
+	Size          int      `json:"size"`
+	Limit         int      `json:"limit"`
+	IsLastPage    bool     `json:"isLastPage"`
+	Start         int      `json:"start"`
+	NextPageStart int      `json:"nextPageStart"`
 }
 
-// GetCurrentUser returns the authenticated user's profile.
+// GroupsResponse is the paginated API response for listing groups with details.
+type GroupsResponse struct {
+	Values        []Group `json:"values"`
+	Size          int     `json:"size"`
+	Limit         int     `json:"limit"`
+	IsLastPage    bool    `json:"isLastPage"`
+	Start         int     `json:"start"`
+	NextPageStart int     `json:"nextPageStart"`
+}
+
+// GetCurrentUser returns the authenticated user's profile, or ErrUnauthorized when
+// Bitbucket rejects the token.
 func (c *Client) GetCurrentUser(ctx context.Context, opts RequestOpts) (*User, error) {
-	var out User
-	if err := c.doJSON(ctx, c.api, http.MethodGet, "/users/current", nil, &out, opts); err != nil {
+	resp, err := c.doClient(ctx, c.api, http.MethodGet, "/users/current", nil, opts)
+	if err != nil {
 		return nil, fmt.Errorf("get current user: %w", err)
 	}
+	if resp.StatusCode() == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("get current user failed: %w", apiError(resp, ""))
+	}
+	var out User
+	if err := json.Unmarshal(resp.Body(), &out); err != nil {
+		return nil, fmt.Errorf("decode current user: %w", err)
+	}
+	return &out, nil
+}
+
+// SearchUsers returns users whose username, display name or email contains filter.
+func (c *Client) SearchUsers(ctx context.Context, filter string, start, limit int, opts RequestOpts) (*UsersResponse, error) {
+	path := "/users" + pagedQuery(url.Values{}, filter, start, limit)
+	var out UsersResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("search users: %w", err)
+	}
+	return &out, nil
+}
+
+// GetUser returns the user with the given slug.
+func (c *Client) GetUser(ctx context.Context, userSlug string, opts RequestOpts) (*User, error) {
+	var out User
+	if err := c.doJSON(ctx, c.api, http.MethodGet, "/users/"+url.PathEscape(userSlug), nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return &out, nil
+}
+
+// ListGroups returns group names containing filter.
+func (c *Client) ListGroups(ctx context.Context, filter string, start, limit int, opts RequestOpts) (*GroupNamesResponse, error) {
+	path := "/groups" + pagedQuery(url.Values{}, filter, start, limit)
+	var out GroupNamesResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
+	return &out, nil
+}
+
+// ListGroupMembers returns users in a group, optionally filtered. Requires ADMIN permission.
+func (c *Client) ListGroupMembers(ctx context.Context, group, filter string, start, limit int, opts RequestOpts) (*UsersResponse, error) {
+	path := "/admin/groups/more-members" + pagedQuery(url.Values{"context": {group}}, filter, start, limit)
+	var out UsersResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list group members: %w", err)
+	}
+	return &out, nil
+}
+
+// ListUserGroups returns the groups a user belongs to, optionally filtered. Requires ADMIN permission.
+func (c *Client) ListUserGroups(ctx context.Context, username, filter string, start, limit int, opts RequestOpts) (*GroupsResponse, error) {
+	path := "/admin/users/more-members" + pagedQuery(url.Values{"context": {username}}, filter, start, limit)
+	var out GroupsResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list user groups: %w", err)
+	}
 	return &out, nil
 }
@@ -2,6 +2,7 @@ package bitbucket
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -43,7 +44,175 @@ func TestGetCurrentUser_Error(t *testing.T) {
 	defer ts.Close()
 
 	_, err := client.GetCurrentUser(context.Background(), RequestOpts{})
-	if err == nil {
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filter") != "alice" || q.Get("start") != "10" || q.Get("limit") != "5" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"asmith","displayName":"Alice Smith","slug":"asmith","type":"NORMAL"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.SearchUsers(context.Background(), "alice", 10, 5, RequestOpts{})
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Name != "asmith" || resp.Values[0].Slug != "asmith" {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+}
+
+func TestSearchUsers_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.SearchUsers(context.Background(), "", 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/asmith", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"asmith","displayName":"Alice Smith","slug":"asmith","active":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	user, err := client.GetUser(context.Background(), "asmith", RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if user.DisplayName != "Alice Smith" {
+		t.Errorf("DisplayName = %q", user.DisplayName)
+	}
+}
+
+func TestGetUser_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/ghost", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetUser(context.Background(), "ghost", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") != "dev" {
+			t.Errorf("filter = %q", r.URL.Query().Get("filter"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":["developers","devops"],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListGroups(context.Background(), "dev", 0, 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListGroups: %v", err)
+	}
+	if len(resp.Values) != 2 || resp.Values[1] != "devops" {
+		t.Errorf("Values = %v", resp.Values)
+	}
+}
+
+func TestListGroups_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListGroups(context.Background(), "", 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListGroupMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/groups/more-members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("context") != "developers" {
+			t.Errorf("context = %q", r.URL.Query().Get("context"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"asmith"},{"name":"bjones"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListGroupMembers(context.Background(), "developers", "", 0, 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListGroupMembers: %v", err)
+	}
+	if len(resp.Values) != 2 {
+		t.Errorf("got %d members", len(resp.Values))
+	}
+}
+
+func TestListGroupMembers_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/groups/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListGroupMembers(context.Background(), "developers", "", 0, 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListUserGroups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/users/more-members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("context") != "asmith" || r.URL.Query().Get("filter") != "dev" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"developers"}],"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListUserGroups(context.Background(), "asmith", "dev", 0, 0, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListUserGroups: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Name != "developers" {
+		t.Errorf("Values = %+v", resp.Values)
+	}
+}
+
+func TestListUserGroups_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/users/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListUserGroups(context.Background(), "asmith", "", 0, 0, RequestOpts{}); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -0,0 +1,132 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Webhook is a repository or project webhook. Events are Bitbucket event keys such as
+// pr:opened, pr:merged or repo:refs_changed.
+type Webhook struct {
+	ID                      int               `json:"id,omitempty"`
+	Name                    string            `json:"name"`
+	URL                     string            `json:"url"`
+	Events                  []string          `json:"events"`
+	Active                  bool              `json:"active"`
+	Configuration           map[string]string `json:"configuration,omitempty"` // "secret" signs deliveries with HMAC
+	SSLVerificationRequired bool              `json:"sslVerificationRequired"`
+	CreatedDate             int64             `json:"createdDate,omitempty"`
+	UpdatedDate             int64             `json:"updatedDate,omitempty"`
+}
+
+// WebhooksResponse is the paged API response for webhooks.
+type WebhooksResponse struct {
+	Values        []Webhook `json:"values"`
+	Size          int       `json:"size"`
+	Limit         int       `json:"limit"`
+	IsLastPage    bool      `json:"isLastPage"`
+	Start         int       `json:"start"`
+	NextPageStart int       `json:"nextPageStart"`
+}
+
+// WebhookMessage is one side of a test delivery.
+type WebhookMessage struct {
+	URL        string         `json:"url,omitempty"`
+	Method     string         `json:"method,omitempty"`
+	StatusCode int            `json:"statusCode,omitempty"`
+	Headers    map[string]any `json:"headers,omitempty"`
+	Body       string         `json:"body,omitempty"`
+}
+
+// WebhookTestResult is the request Bitbucket sent for a test delivery and the response it got.
+type WebhookTestResult struct {
+	Request  *WebhookMessage `json:"request,omitempty"`
+	Response *WebhookMessage `json:"response,omitempty"`
+}
+
+// webhooksPath returns the webhooks path of a project, or of a repository when repoSlug is set.
+func webhooksPath(projectKey, repoSlug string) string {
+	path := "/projects/" + url.PathEscape(projectKey)
+	if repoSlug != "" {
+		path += "/repos/" + url.PathEscape(repoSlug)
+	}
+	return path + "/webhooks"
+}
+
+// ListWebhooks returns the webhooks of a project, or of a repository when repoSlug is set.
+// A non-empty event limits the result to webhooks subscribed to it.
+func (c *Client) ListWebhooks(ctx context.Context, projectKey, repoSlug, event string, start int, opts RequestOpts) (*WebhooksResponse, error) {
+	q := url.Values{}
+	if event != "" {
+		q.Set("event", event)
+	}
+	path := webhooksPath(projectKey, repoSlug) + pagedQuery(q, "", start, 0)
+	var out WebhooksResponse
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	return &out, nil
+}
+
+// GetWebhook returns a webhook by ID.
+func (c *Client) GetWebhook(ctx context.Context, projectKey, repoSlug string, id int, opts RequestOpts) (*Webhook, error) {
+	path := webhooksPath(projectKey, repoSlug) + "/" + strconv.Itoa(id)
+	var out Webhook
+	if err := c.doJSON(ctx, c.api, http.MethodGet, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+	return &out, nil
+}
+
+// CreateWebhook creates a webhook. Requires admin permission on the project or repository.
+func (c *Client) CreateWebhook(ctx context.Context, projectKey, repoSlug string, hook Webhook, opts RequestOpts) (*Webhook, error) {
+	var out Webhook
+	if err := c.doJSON(ctx, c.api, http.MethodPost, webhooksPath(projectKey, repoSlug), hook, &out, opts); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+	return &out, nil
+}
+
+// UpdateWebhook replaces the webhook with the given ID.
+func (c *Client) UpdateWebhook(ctx context.Context, projectKey, repoSlug string, id int, hook Webhook, opts RequestOpts) (*Webhook, error) {
+	path := webhooksPath(projectKey, repoSlug) + "/" + strconv.Itoa(id)
+	var out Webhook
+	if err := c.doJSON(ctx, c.api, http.MethodPut, path, hook, &out, opts); err != nil {
+		return nil, fmt.Errorf("update webhook: %w", err)
+	}
+	return &out, nil
+}
+
+// DeleteWebhook deletes a webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, projectKey, repoSlug string, id int, opts RequestOpts) error {
+	path := webhooksPath(projectKey, repoSlug) + "/" + strconv.Itoa(id)
+	resp, err := c.do(ctx, http.MethodDelete, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("delete webhook failed: %w", apiError(resp, ""))
+	}
+	return nil
+}
+
+// TestWebhook asks Bitbucket to send a test delivery, either to an existing webhook
+// (id > 0) or to targetURL, and returns what was sent and received.
+func (c *Client) TestWebhook(ctx context.Context, projectKey, repoSlug string, id int, targetURL string, opts RequestOpts) (*WebhookTestResult, error) {
+	q := url.Values{}
+	if id > 0 {
+		q.Set("webhookId", strconv.Itoa(id))
+	}
+	if targetURL != "" {
+		q.Set("url", targetURL)
+	}
+	path := webhooksPath(projectKey, repoSlug) + "/test" + pagedQuery(q, "", 0, 0)
+	var out WebhookTestResult
+	if err := c.doJSON(ctx, c.api, http.MethodPost, path, nil, &out, opts); err != nil {
+		return nil, fmt.Errorf("test webhook: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,241 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const webhookJSON = `{"id":7,"name":"ci","url":"https://ci.example.com/hook","events":["pr:opened","repo:refs_changed"],"active":true,"configuration":{"secret":"s3cret"},"sslVerificationRequired":true}`
+
+func TestWebhooksPath(t *testing.T) {
+	if got := webhooksPath("PROJ", ""); got != "/projects/PROJ/webhooks" {
+		t.Errorf("project path = %q", got)
+	}
+	if got := webhooksPath("PROJ", "my repo"); got != "/projects/PROJ/repos/my%20repo/webhooks" {
+		t.Errorf("repo path = %q", got)
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("event"); got != "pr:opened" {
+			t.Errorf("event = %q", got)
+		}
+		if got := r.URL.Query().Get("start"); got != "25" {
+			t.Errorf("start = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[` + webhookJSON + `],"size":1,"isLastPage":true}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	resp, err := client.ListWebhooks(context.Background(), "PROJ", "repo", "pr:opened", 25, RequestOpts{})
+	if err != nil {
+		t.Fatalf("ListWebhooks: %v", err)
+	}
+	if len(resp.Values) != 1 || resp.Values[0].Configuration["secret"] != "s3cret" || len(resp.Values[0].Events) != 2 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestListWebhooks_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.ListWebhooks(context.Background(), "PROJ", "", "", 0, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	hook, err := client.GetWebhook(context.Background(), "PROJ", "", 7, RequestOpts{})
+	if err != nil {
+		t.Fatalf("GetWebhook: %v", err)
+	}
+	if hook.ID != 7 || !hook.Active {
+		t.Errorf("hook = %+v", hook)
+	}
+}
+
+func TestGetWebhook_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.GetWebhook(context.Background(), "PROJ", "", 7, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body Webhook
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body.URL != "https://ci.example.com/hook" || body.Configuration["secret"] != "s3cret" || !body.Active {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	hook, err := client.CreateWebhook(context.Background(), "PROJ", "repo", Webhook{
+		Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"pr:opened"}, Active: true,
+		Configuration: map[string]string{"secret": "s3cret"},
+	}, RequestOpts{})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if hook.ID != 7 {
+		t.Errorf("ID = %d", hook.ID)
+	}
+}
+
+func TestCreateWebhook_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"A webhook with this name already exists"}]}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.CreateWebhook(context.Background(), "PROJ", "repo", Webhook{Name: "ci"}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.UpdateWebhook(context.Background(), "PROJ", "repo", 7, Webhook{Name: "ci"}, RequestOpts{}); err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+}
+
+func TestUpdateWebhook_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.UpdateWebhook(context.Background(), "PROJ", "repo", 7, Webhook{}, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.WriteHeader(204)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteWebhook(context.Background(), "PROJ", "repo", 7, RequestOpts{}); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+}
+
+func TestDeleteWebhook_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if err := client.DeleteWebhook(context.Background(), "PROJ", "repo", 7, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteWebhook_TransportError(t *testing.T) {
+	client, ts := newTestServer(http.NewServeMux())
+	ts.Close()
+
+	if err := client.DeleteWebhook(context.Background(), "PROJ", "repo", 7, RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestTestWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/test", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		if got := r.URL.Query().Get("webhookId"); got != "7" {
+			t.Errorf("webhookId = %q", got)
+		}
+		if got := r.URL.Query().Get("url"); got != "https://ci.example.com/hook" {
+			t.Errorf("url = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"request":{"url":"https://ci.example.com/hook","method":"POST"},"response":{"statusCode":200,"body":"ok"}}`))
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	result, err := client.TestWebhook(context.Background(), "PROJ", "repo", 7, "https://ci.example.com/hook", RequestOpts{})
+	if err != nil {
+		t.Fatalf("TestWebhook: %v", err)
+	}
+	if result.Response == nil || result.Response.StatusCode != 200 || result.Request.Method != "POST" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestTestWebhook_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	client, ts := newTestServer(mux)
+	defer ts.Close()
+
+	if _, err := client.TestWebhook(context.Background(), "PROJ", "", 0, "", RequestOpts{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds server configuration from environment.
@@ -17,8 +18,27 @@ type Config struct {
 	ExtraHeaders      map[string]string
 	DefaultProjectKey string
 	LogLevel          string // "info" (default), "debug", or "off" - BITBUCKET_LOG_LEVEL
+	WebhookSecret     string // Shared secret for inbound Bitbucket webhooks; the receiver is disabled when empty
+	WebhookEndpoint   string // Path of the webhook receiver. Default: /webhooks/bitbucket
+	Token             string // BITBUCKET_TOKEN or _FILE: server-held Bitbucket token for the stdio transport and service mode
+	AuthMode          string // MCP_AUTH_MODE: "passthrough" (default), "verify" (passthrough checked against Bitbucket) or "service"
+	APIKeys           map[string]string // Client name → API key accepted in service mode (MCP_API_KEYS=name=key,...)
+	AuthCacheTTL      time.Duration // How long a verified token's user is cached in verify mode. Default: 5m
+	AuthNegativeTTL   time.Duration // How long a token Bitbucket rejected stays rejected in verify mode. Default: 30s
+	OAuthClientID     string        // BITBUCKET_OAUTH_CLIENT_ID: client id of a Bitbucket incoming application link; enables OAuth sign-in
+	OAuthClientSecret string        // BITBUCKET_OAUTH_CLIENT_SECRET: kept server-side; token requests are proxied when set
+	OAuthRedirectURL  string        // BITBUCKET_OAUTH_REDIRECT_URL: redirect URL of the application link; the only one clients may register
+	BasicAuth         bool          // MCP_BASIC_AUTH: accept "Authorization: Basic" and forward it to Bitbucket
+	BasicAuthHeaders  []string      // MCP_BASIC_AUTH_HEADERS: username and password header names, an alternative to Basic
 }
 
+// Auth modes selecting the token verifier of the HTTP transport.
+const (
+	AuthModePassthrough = "passthrough"
+	AuthModeVerify      = "verify"
+	AuthModeService     = "service"
+)
+
 // Load reads configuration from environment variables.
 func Load() (*Config, error) {
 	bitbucketURL := os.Getenv("BITBUCKET_URL")
@@ -75,6 +95,81 @@ func Load() (*Config, error) {
 		return nil, &ConfigError{Field: "MCP_PUBLIC_URL", Msg: "invalid URL (use e.g. https://mcp.example.com)"}
 	}
 
+	webhookEndpoint := "/webhooks/bitbucket"
+	if e := strings.Trim(os.Getenv("MCP_WEBHOOK_ENDPOINT"), "/"); e != "" {
+		webhookEndpoint = "/" + e
+	}
+	if webhookEndpoint == endpoint {
+		return nil, &ConfigError{Field: "MCP_WEBHOOK_ENDPOINT", Msg: "must differ from MCP_HTTP_ENDPOINT"}
+	}
+
+	authMode := strings.ToLower(strings.TrimSpace(os.Getenv("MCP_AUTH_MODE")))
+	if authMode == "" {
+		authMode = AuthModePassthrough
+	}
+	if authMode != AuthModePassthrough && authMode != AuthModeVerify && authMode != AuthModeService {
+		return nil, &ConfigError{Field: "MCP_AUTH_MODE", Msg: "must be passthrough, verify, or service"}
+	}
+	cacheTTL, err := parseDuration("MCP_AUTH_CACHE_TTL", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	negativeTTL, err := parseDuration("MCP_AUTH_NEGATIVE_CACHE_TTL", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	token, err := loadToken()
+	if err != nil {
+		return nil, err
+	}
+	apiKeys, err := parseAPIKeys(os.Getenv("MCP_API_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	if authMode == AuthModeService {
+		if token == "" {
+			return nil, &ConfigError{Field: "BITBUCKET_TOKEN", Msg: "required when MCP_AUTH_MODE=service"}
+		}
+		if len(apiKeys) == 0 {
+			return nil, &ConfigError{Field: "MCP_API_KEYS", Msg: "required when MCP_AUTH_MODE=service"}
+		}
+	}
+
+	oauthClientID := strings.TrimSpace(os.Getenv("BITBUCKET_OAUTH_CLIENT_ID"))
+	oauthClientSecret := strings.TrimSpace(os.Getenv("BITBUCKET_OAUTH_CLIENT_SECRET"))
+	if oauthClientSecret != "" && oauthClientID == "" {
+		return nil, &ConfigError{Field: "BITBUCKET_OAUTH_CLIENT_ID", Msg: "required with BITBUCKET_OAUTH_CLIENT_SECRET"}
+	}
+	if oauthClientID != "" && authMode == AuthModeService {
+		return nil, &ConfigError{Field: "BITBUCKET_OAUTH_CLIENT_ID", Msg: "OAuth sign-in needs MCP_AUTH_MODE passthrough or verify"}
+	}
+	oauthRedirectURL := strings.TrimSpace(os.Getenv("BITBUCKET_OAUTH_REDIRECT_URL"))
+	if oauthClientID != "" {
+		if oauthRedirectURL == "" {
+			return nil, &ConfigError{Field: "BITBUCKET_OAUTH_REDIRECT_URL", Msg: "required with BITBUCKET_OAUTH_CLIENT_ID"}
+		}
+		if u, err := url.Parse(oauthRedirectURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, &ConfigError{Field: "BITBUCKET_OAUTH_REDIRECT_URL", Msg: "invalid URL (use the redirect URL of the application link)"}
+		}
+	}
+
+	basicAuth := false
+	if v := strings.TrimSpace(os.Getenv("MCP_BASIC_AUTH")); v != "" {
+		if basicAuth, err = strconv.ParseBool(v); err != nil {
+			return nil, &ConfigError{Field: "MCP_BASIC_AUTH", Msg: "must be true or false"}
+		}
+	}
+	basicHeaders := parseList(os.Getenv("MCP_BASIC_AUTH_HEADERS"))
+	if len(basicHeaders) > 0 {
+		if len(basicHeaders) != 2 || strings.EqualFold(basicHeaders[0], basicHeaders[1]) {
+			return nil, &ConfigError{Field: "MCP_BASIC_AUTH_HEADERS", Msg: "use two header names: username,password"}
+		}
+		basicAuth = true
+	}
+	if basicAuth && authMode == AuthModeService {
+		return nil, &ConfigError{Field: "MCP_BASIC_AUTH", Msg: "not available with MCP_AUTH_MODE=service"}
+	}
+
 	return &Config{
 		BitbucketURL:       bitbucketURL,
 		MCPHTTPPort:        port,
@@ -84,9 +179,73 @@ func Load() (*Config, error) {
 		ExtraHeaders:       extraHeaders,
 		DefaultProjectKey:  defaultProject,
 		LogLevel:           logLevel,
+		WebhookSecret:      os.Getenv("BITBUCKET_WEBHOOK_SECRET"),
+		WebhookEndpoint:    webhookEndpoint,
+		Token:              token,
+		AuthMode:           authMode,
+		APIKeys:            apiKeys,
+		AuthCacheTTL:       cacheTTL,
+		AuthNegativeTTL:    negativeTTL,
+		OAuthClientID:      oauthClientID,
+		OAuthClientSecret:  oauthClientSecret,
+		OAuthRedirectURL:   oauthRedirectURL,
+		BasicAuth:          basicAuth,
+		BasicAuthHeaders:   basicHeaders,
 	}, nil
 }
 
+// parseDuration reads a positive Go duration (e.g. 90s, 5m) from env, or returns def when unset.
+func parseDuration(env string, def time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(os.Getenv(env))
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, &ConfigError{Field: env, Msg: "must be a positive duration (e.g. 30s, 5m)"}
+	}
+	return d, nil
+}
+
+// loadToken reads the server-held Bitbucket token from BITBUCKET_TOKEN or from the
+// file named by BITBUCKET_TOKEN_FILE (e.g. a mounted secret).
+func loadToken() (string, error) {
+	token := strings.TrimSpace(os.Getenv("BITBUCKET_TOKEN"))
+	file := strings.TrimSpace(os.Getenv("BITBUCKET_TOKEN_FILE"))
+	if file == "" {
+		return token, nil
+	}
+	if token != "" {
+		return "", &ConfigError{Field: "BITBUCKET_TOKEN_FILE", Msg: "set either BITBUCKET_TOKEN or BITBUCKET_TOKEN_FILE"}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", &ConfigError{Field: "BITBUCKET_TOKEN_FILE", Msg: err.Error()}
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// parseAPIKeys parses "name=key,name2=key2" into a name → key map.
+func parseAPIKeys(s string) (map[string]string, error) {
+	keys := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, entry := range parseList(s) {
+		name, key, ok := strings.Cut(entry, "=")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, &ConfigError{Field: "MCP_API_KEYS", Msg: "use name=key pairs separated by commas"}
+		}
+		if _, dup := keys[name]; dup {
+			return nil, &ConfigError{Field: "MCP_API_KEYS", Msg: "duplicate name " + name}
+		}
+		if seen[key] {
+			return nil, &ConfigError{Field: "MCP_API_KEYS", Msg: "key of " + name + " is already used"}
+		}
+		keys[name], seen[key] = key, true
+	}
+	return keys, nil
+}
+
 func validProjectKey(s string) bool {
 	if len(s) == 0 || len(s) > 255 {
 		return false
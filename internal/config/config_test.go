@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func clearEnv() {
@@ -10,6 +12,11 @@ func clearEnv() {
 		"BITBUCKET_URL", "MCP_HTTP_PORT", "MCP_HTTP_ENDPOINT", "MCP_PUBLIC_URL",
 		"BITBUCKET_PROXY_HEADERS", "BITBUCKET_DEFAULT_PROJECT",
 		"BITBUCKET_LOG_LEVEL", "BITBUCKET_DEBUG",
+		"BITBUCKET_WEBHOOK_SECRET", "MCP_WEBHOOK_ENDPOINT", "BITBUCKET_TOKEN",
+		"MCP_AUTH_MODE", "BITBUCKET_TOKEN_FILE", "MCP_API_KEYS",
+		"MCP_AUTH_CACHE_TTL", "MCP_AUTH_NEGATIVE_CACHE_TTL",
+		"BITBUCKET_OAUTH_CLIENT_ID", "BITBUCKET_OAUTH_CLIENT_SECRET", "BITBUCKET_OAUTH_REDIRECT_URL",
+		"MCP_BASIC_AUTH", "MCP_BASIC_AUTH_HEADERS",
 	} {
 		_ = os.Unsetenv(key)
 	}
@@ -39,6 +46,9 @@ func TestLoad(t *testing.T) {
 	if cfg.MCPPublicURL != "http://localhost:3001" {
 		t.Errorf("MCPPublicURL = %q, want http://localhost:3001", cfg.MCPPublicURL)
 	}
+	if cfg.WebhookSecret != "" || cfg.WebhookEndpoint != "/webhooks/bitbucket" {
+		t.Errorf("webhook = %q %q, want disabled at /webhooks/bitbucket", cfg.WebhookSecret, cfg.WebhookEndpoint)
+	}
 }
 
 func TestLoad_MissingURL(t *testing.T) {
@@ -362,3 +372,171 @@ func TestConfigError(t *testing.T) {
 		t.Errorf("Error = %q", e.Error())
 	}
 }
+
+func TestLoad_Webhook(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("BITBUCKET_WEBHOOK_SECRET", "s3cret")
+	_ = os.Setenv("MCP_WEBHOOK_ENDPOINT", "hooks/")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.WebhookSecret != "s3cret" {
+		t.Errorf("WebhookSecret = %q", cfg.WebhookSecret)
+	}
+	if cfg.WebhookEndpoint != "/hooks" {
+		t.Errorf("WebhookEndpoint = %q, want /hooks", cfg.WebhookEndpoint)
+	}
+}
+
+func TestLoad_WebhookEndpointClash(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("MCP_WEBHOOK_ENDPOINT", "/mcp")
+	defer clearEnv()
+
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error when webhook endpoint equals MCP endpoint")
+	}
+}
+
+func TestLoad_Token(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("BITBUCKET_TOKEN", " pat-123\n")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Token != "pat-123" {
+		t.Errorf("Token = %q, want pat-123", cfg.Token)
+	}
+}
+
+func TestLoad_AuthModeDefault(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AuthMode != AuthModePassthrough {
+		t.Errorf("AuthMode = %q, want passthrough", cfg.AuthMode)
+	}
+	if cfg.AuthCacheTTL != 5*time.Minute || cfg.AuthNegativeTTL != 30*time.Second {
+		t.Errorf("TTLs = %v, %v", cfg.AuthCacheTTL, cfg.AuthNegativeTTL)
+	}
+}
+
+func TestLoad_VerifyMode(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("MCP_AUTH_MODE", "verify")
+	_ = os.Setenv("MCP_AUTH_CACHE_TTL", "90s")
+	_ = os.Setenv("MCP_AUTH_NEGATIVE_CACHE_TTL", "5s")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AuthMode != AuthModeVerify || cfg.AuthCacheTTL != 90*time.Second || cfg.AuthNegativeTTL != 5*time.Second {
+		t.Errorf("cfg = %q %v %v", cfg.AuthMode, cfg.AuthCacheTTL, cfg.AuthNegativeTTL)
+	}
+}
+
+func TestLoad_ServiceMode(t *testing.T) {
+	clearEnv()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("service-pat\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("MCP_AUTH_MODE", "Service")
+	_ = os.Setenv("BITBUCKET_TOKEN_FILE", tokenFile)
+	_ = os.Setenv("MCP_API_KEYS", "n8n=key-1, ci = key-2")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AuthMode != AuthModeService || cfg.Token != "service-pat" {
+		t.Errorf("AuthMode = %q, Token = %q", cfg.AuthMode, cfg.Token)
+	}
+	if len(cfg.APIKeys) != 2 || cfg.APIKeys["n8n"] != "key-1" || cfg.APIKeys["ci"] != "key-2" {
+		t.Errorf("APIKeys = %v", cfg.APIKeys)
+	}
+}
+
+func TestLoad_ServiceModeErrors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"unknown mode":      {"MCP_AUTH_MODE": "ldap"},
+		"no token":          {"MCP_AUTH_MODE": "service", "MCP_API_KEYS": "n8n=k"},
+		"no keys":           {"MCP_AUTH_MODE": "service", "BITBUCKET_TOKEN": "pat"},
+		"both token vars":   {"BITBUCKET_TOKEN": "pat", "BITBUCKET_TOKEN_FILE": "/tmp/token"},
+		"missing file":      {"BITBUCKET_TOKEN_FILE": "/nonexistent/token"},
+		"malformed key":     {"MCP_API_KEYS": "n8n"},
+		"duplicate name":    {"MCP_API_KEYS": "n8n=a,n8n=b"},
+		"duplicate secret":  {"MCP_API_KEYS": "n8n=a,ci=a"},
+		"bad cache TTL":     {"MCP_AUTH_CACHE_TTL": "5"},
+		"zero negative":     {"MCP_AUTH_NEGATIVE_CACHE_TTL": "0s"},
+		"secret only":       {"BITBUCKET_OAUTH_CLIENT_SECRET": "s"},
+		"bad basic flag":    {"MCP_BASIC_AUTH": "maybe"},
+		"one basic header":  {"MCP_BASIC_AUTH_HEADERS": "X-User"},
+		"basic in service":  {"MCP_AUTH_MODE": "service", "BITBUCKET_TOKEN": "pat", "MCP_API_KEYS": "n8n=k", "MCP_BASIC_AUTH": "true"},
+		"oauth in service":  {"MCP_AUTH_MODE": "service", "BITBUCKET_TOKEN": "pat", "MCP_API_KEYS": "n8n=k", "BITBUCKET_OAUTH_CLIENT_ID": "id"},
+		"oauth no redirect": {"BITBUCKET_OAUTH_CLIENT_ID": "id"},
+		"bad redirect":      {"BITBUCKET_OAUTH_CLIENT_ID": "id", "BITBUCKET_OAUTH_REDIRECT_URL": "/callback"},
+	}
+	for name, env := range tests {
+		clearEnv()
+		_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+		for k, v := range env {
+			_ = os.Setenv(k, v)
+		}
+		if _, err := Load(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	clearEnv()
+}
+
+func TestLoad_OAuth(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("BITBUCKET_OAUTH_CLIENT_ID", " client-1 ")
+	_ = os.Setenv("BITBUCKET_OAUTH_CLIENT_SECRET", "s3cret")
+	_ = os.Setenv("BITBUCKET_OAUTH_REDIRECT_URL", "http://127.0.0.1:33418/")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.OAuthClientID != "client-1" || cfg.OAuthClientSecret != "s3cret" || cfg.OAuthRedirectURL != "http://127.0.0.1:33418/" {
+		t.Errorf("OAuth = %q %q %q", cfg.OAuthClientID, cfg.OAuthClientSecret, cfg.OAuthRedirectURL)
+	}
+}
+
+func TestLoad_BasicAuth(t *testing.T) {
+	clearEnv()
+	_ = os.Setenv("BITBUCKET_URL", "https://bitbucket.example.com")
+	_ = os.Setenv("MCP_BASIC_AUTH_HEADERS", "X-Bitbucket-User, X-Bitbucket-Token")
+	defer clearEnv()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.BasicAuth || len(cfg.BasicAuthHeaders) != 2 || cfg.BasicAuthHeaders[1] != "X-Bitbucket-Token" {
+		t.Errorf("BasicAuth = %v, headers = %v", cfg.BasicAuth, cfg.BasicAuthHeaders)
+	}
+}
@@ -0,0 +1,424 @@
+package mcp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const (
+	// eventsURI is the resource holding recent webhook events across all repositories.
+	// Per-repository events live at eventsURI + "/{project}/{repository}".
+	eventsURI = "bitbucket://events"
+	// maxRecentEvents bounds the in-memory event history.
+	maxRecentEvents = 100
+	// maxWebhookBody bounds the size of a webhook delivery.
+	maxWebhookBody = 5 << 20
+	// repoAccessTTL is how long a session's read access to a repository is remembered.
+	repoAccessTTL = time.Minute
+	// webhookNotifyTimeout bounds the notification fan-out for one delivery, which runs
+	// after the delivery has been answered.
+	webhookNotifyTimeout = 30 * time.Second
+)
+
+// WebhookEvent is a Bitbucket webhook delivery reduced to what an agent needs to react to it.
+type WebhookEvent struct {
+	Event         string      `json:"event"`
+	Date          string      `json:"date,omitempty"`
+	Actor         string      `json:"actor,omitempty"`
+	Project       string      `json:"project,omitempty"`
+	Repository    string      `json:"repository,omitempty"`
+	PullRequestID int         `json:"pullRequestId,omitempty"`
+	Title         string      `json:"title,omitempty"`
+	State         string      `json:"state,omitempty"`
+	FromBranch    string      `json:"fromBranch,omitempty"`
+	ToBranch      string      `json:"toBranch,omitempty"`
+	CommentID     int         `json:"commentId,omitempty"`
+	Comment       string      `json:"comment,omitempty"`
+	Changes       []RefChange `json:"changes,omitempty"`
+	URI           string      `json:"uri"`
+}
+
+// RefChange is one ref update in a repo:refs_changed event.
+type RefChange struct {
+	Ref      string `json:"ref"`
+	Type     string `json:"type"` // ADD, UPDATE or DELETE
+	FromHash string `json:"fromHash,omitempty"`
+	ToHash   string `json:"toHash,omitempty"`
+}
+
+// webhookPayload covers the fields of the Bitbucket event payloads we normalize.
+type webhookPayload struct {
+	EventKey    string                 `json:"eventKey"`
+	Date        string                 `json:"date"`
+	Actor       *bitbucket.User        `json:"actor"`
+	Repository  *bitbucket.Repository  `json:"repository"`
+	PullRequest *bitbucket.PullRequest `json:"pullRequest"`
+	Comment     *struct {
+		ID   int    `json:"id"`
+		Text string `json:"text"`
+	} `json:"comment"`
+	Changes []struct {
+		Ref *struct {
+			DisplayID string `json:"displayId"`
+		} `json:"ref"`
+		RefID    string `json:"refId"`
+		FromHash string `json:"fromHash"`
+		ToHash   string `json:"toHash"`
+		Type     string `json:"type"`
+	} `json:"changes"`
+}
+
+// normalizeEvent converts a webhook payload into a WebhookEvent. eventKey comes from the
+// X-Event-Key header and takes precedence over the payload's own eventKey.
+func normalizeEvent(eventKey string, p webhookPayload) WebhookEvent {
+	ev := WebhookEvent{Event: eventKey, Date: p.Date}
+	if ev.Event == "" {
+		ev.Event = p.EventKey
+	}
+	if p.Actor != nil {
+		ev.Actor = p.Actor.Name
+	}
+	repo := p.Repository
+	if pr := p.PullRequest; pr != nil {
+		ev.PullRequestID = pr.ID
+		ev.Title = pr.Title
+		ev.State = pr.State
+		if pr.FromRef != nil {
+			ev.FromBranch = pr.FromRef.DisplayID
+		}
+		if pr.ToRef != nil {
+			ev.ToBranch = pr.ToRef.DisplayID
+			if repo == nil {
+				repo = pr.ToRef.Repository
+			}
+		}
+	}
+	if repo != nil {
+		ev.Repository = repo.Slug
+		if repo.Project != nil {
+			ev.Project = repo.Project.Key
+		}
+	}
+	if p.Comment != nil {
+		ev.CommentID = p.Comment.ID
+		ev.Comment = p.Comment.Text
+	}
+	for _, c := range p.Changes {
+		ref := c.RefID
+		if c.Ref != nil && c.Ref.DisplayID != "" {
+			ref = c.Ref.DisplayID
+		}
+		ev.Changes = append(ev.Changes, RefChange{Ref: ref, Type: c.Type, FromHash: c.FromHash, ToHash: c.ToHash})
+	}
+	ev.URI = eventsURI
+	if ev.Project != "" && ev.Repository != "" {
+		ev.URI = repoEventsURI(ev.Project, ev.Repository)
+	}
+	return ev
+}
+
+func repoEventsURI(project, repo string) string {
+	return eventsURI + "/" + project + "/" + repo
+}
+
+// eventLog keeps the most recent webhook events in memory.
+type eventLog struct {
+	mu     sync.Mutex
+	events []WebhookEvent
+}
+
+func (l *eventLog) add(ev WebhookEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, ev)
+	if len(l.events) > maxRecentEvents {
+		l.events = l.events[len(l.events)-maxRecentEvents:]
+	}
+}
+
+// recent returns stored events, newest first: all of them for eventsURI, otherwise
+// those for the repository identified by uri.
+func (l *eventLog) recent(uri string) []WebhookEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := []WebhookEvent{}
+	for i := len(l.events) - 1; i >= 0; i-- {
+		if uri == eventsURI || l.events[i].URI == uri {
+			out = append(out, l.events[i])
+		}
+	}
+	return out
+}
+
+// eventSubscribers records, per session, the event resources it subscribed to and the
+// credentials its events are filtered with.
+type eventSubscribers struct {
+	mu       sync.Mutex
+	sessions map[*mcp.ServerSession]*eventSubscriber
+}
+
+type eventSubscriber struct {
+	opts bitbucket.RequestOpts
+	uris map[string]bool
+}
+
+func (e *eventSubscribers) subscribe(ss *mcp.ServerSession, uri string, opts bitbucket.RequestOpts) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sessions == nil {
+		e.sessions = make(map[*mcp.ServerSession]*eventSubscriber)
+	}
+	sub := e.sessions[ss]
+	if sub == nil {
+		sub = &eventSubscriber{uris: make(map[string]bool)}
+		e.sessions[ss] = sub
+	}
+	sub.opts = opts
+	sub.uris[uri] = true
+}
+
+func (e *eventSubscribers) unsubscribe(ss *mcp.ServerSession, uri string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if sub := e.sessions[ss]; sub != nil {
+		delete(sub.uris, uri)
+		if len(sub.uris) == 0 {
+			delete(e.sessions, ss)
+		}
+	}
+}
+
+// matching returns the credentials of live sessions subscribed to eventsURI or uri,
+// forgetting sessions that have closed.
+func (e *eventSubscribers) matching(live map[*mcp.ServerSession]bool, uri string) map[*mcp.ServerSession]bitbucket.RequestOpts {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make(map[*mcp.ServerSession]bitbucket.RequestOpts)
+	for ss, sub := range e.sessions {
+		if !live[ss] {
+			delete(e.sessions, ss)
+			continue
+		}
+		if sub.uris[eventsURI] || sub.uris[uri] {
+			out[ss] = sub.opts
+		}
+	}
+	return out
+}
+
+// repoAccessCache remembers whether a set of credentials can read a repository.
+type repoAccessCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]repoAccess
+}
+
+type repoAccess struct {
+	allowed bool
+	expires time.Time
+}
+
+func repoAccessKey(opts bitbucket.RequestOpts, project, repo string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(opts.Username + "\x00" + opts.Token + "\x00" + project + "/" + repo))
+}
+
+// canReadRepo reports whether opts may read project/repo, asking Bitbucket at most once
+// per repoAccessTTL. Events without a repository are never shown: they cannot be checked.
+func (s *Server) canReadRepo(ctx context.Context, opts bitbucket.RequestOpts, project, repo string) bool {
+	if project == "" || repo == "" {
+		return false
+	}
+	key := repoAccessKey(opts, project, repo)
+	now := time.Now()
+	s.access.mu.Lock()
+	if a, ok := s.access.entries[key]; ok && now.Before(a.expires) {
+		s.access.mu.Unlock()
+		return a.allowed
+	}
+	s.access.mu.Unlock()
+
+	_, err := s.client.GetRepository(ctx, project, repo, opts)
+	s.access.mu.Lock()
+	defer s.access.mu.Unlock()
+	if s.access.entries == nil {
+		s.access.entries = make(map[[sha256.Size]byte]repoAccess)
+	}
+	for k, a := range s.access.entries {
+		if !now.Before(a.expires) {
+			delete(s.access.entries, k)
+		}
+	}
+	s.access.entries[key] = repoAccess{allowed: err == nil, expires: now.Add(repoAccessTTL)}
+	return err == nil
+}
+
+// parseEventsURI splits a per-repository events URI into project and repository.
+func parseEventsURI(uri string) (project, repo string, ok bool) {
+	rest, ok := strings.CutPrefix(uri, eventsURI+"/")
+	if !ok {
+		return "", "", false
+	}
+	project, repo, ok = strings.Cut(rest, "/")
+	return project, repo, ok && project != "" && repo != "" && !strings.Contains(repo, "/")
+}
+
+// subscribeEvents accepts subscriptions to eventsURI, and to a repository's events only
+// when the session's credentials can read that repository.
+func (s *Server) subscribeEvents(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	opts := s.extraOpts(ctx, req.Extra)
+	if uri != eventsURI {
+		project, repo, ok := parseEventsURI(uri)
+		if !ok {
+			return fmt.Errorf("unknown resource %q", uri)
+		}
+		if !s.canReadRepo(ctx, opts, project, repo) {
+			return fmt.Errorf("cannot read repository %s/%s", project, repo)
+		}
+	}
+	s.subscribers.subscribe(req.Session, uri, opts)
+	return nil
+}
+
+func (s *Server) unsubscribeEvents(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	s.subscribers.unsubscribe(req.Session, req.Params.URI)
+	return nil
+}
+
+func (s *Server) registerEventResources() {
+	s.mcpServer.AddResource(&mcp.Resource{
+		URI:         eventsURI,
+		Name:        "bitbucket_events",
+		Description: "Recent Bitbucket webhook events (newest first). Subscribe to be notified of new ones.",
+		MIMEType:    "application/json",
+	}, s.readEvents)
+	s.mcpServer.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: eventsURI + "/{project}/{repository}",
+		Name:        "bitbucket_repository_events",
+		Description: "Recent Bitbucket webhook events for one repository (newest first)",
+		MIMEType:    "application/json",
+	}, s.readEvents)
+}
+
+// readEvents returns the stored events of the requested resource that the caller's
+// credentials can read.
+func (s *Server) readEvents(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	opts := s.extraOpts(ctx, req.Extra)
+	events := []WebhookEvent{}
+	for _, ev := range s.events.recent(uri) {
+		if s.canReadRepo(ctx, opts, ev.Project, ev.Repository) {
+			events = append(events, ev)
+		}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+		{URI: uri, MIMEType: "application/json", Text: string(data)},
+	}}, nil
+}
+
+// publishEvent records an event and notifies subscribers in the background, so the webhook
+// delivery is answered without waiting on the per-session access checks.
+func (s *Server) publishEvent(ev WebhookEvent) {
+	s.events.add(ev)
+	s.notifying.Add(1)
+	go func() {
+		defer s.notifying.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), webhookNotifyTimeout)
+		defer cancel()
+		s.notifyEvent(ctx, ev)
+	}()
+}
+
+// notifyEvent notifies subscribers of the affected resources and sends the event as a log
+// message to subscribed sessions whose credentials can read its repository.
+// Repository subscriptions were access-checked in subscribeEvents, and the eventsURI
+// notification carries no event data.
+func (s *Server) notifyEvent(ctx context.Context, ev WebhookEvent) {
+	_ = s.mcpServer.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: eventsURI})
+	if ev.URI != eventsURI {
+		_ = s.mcpServer.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: ev.URI})
+	}
+	live := make(map[*mcp.ServerSession]bool)
+	for session := range s.mcpServer.Sessions() {
+		live[session] = true
+	}
+	for session, opts := range s.subscribers.matching(live, ev.URI) {
+		if s.canReadRepo(ctx, opts, ev.Project, ev.Repository) {
+			_ = session.Log(ctx, &mcp.LoggingMessageParams{Level: "info", Logger: "bitbucket.webhook", Data: ev})
+		}
+	}
+}
+
+// WebhookHandler returns the HTTP handler for Bitbucket webhook deliveries. Deliveries must
+// carry an X-Hub-Signature HMAC of the body keyed with secret; others are rejected.
+func (s *Server) WebhookHandler(secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "delivery too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, "read body", http.StatusBadRequest)
+			return
+		}
+		if !validSignature(secret, body, r.Header.Get("X-Hub-Signature")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		eventKey := r.Header.Get("X-Event-Key")
+		if eventKey == "diagnostics:ping" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		ev := normalizeEvent(eventKey, payload)
+		if ev.Event == "" {
+			http.Error(w, "missing event key", http.StatusBadRequest)
+			return
+		}
+		s.publishEvent(ev)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// validSignature checks an X-Hub-Signature header ("sha256=<hex>") against the HMAC of body.
+func validSignature(secret string, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok || secret == "" {
+		return false
+	}
+	want, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), want)
+}
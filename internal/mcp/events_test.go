@@ -0,0 +1,345 @@
+package mcp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const (
+	webhookSecret = "s3cret"
+	prOpenedJSON  = `{"eventKey":"pr:opened","date":"2026-01-02T10:00:00+0000","actor":{"name":"alice"},
+		"pullRequest":{"id":42,"title":"Add feature","state":"OPEN",
+			"fromRef":{"id":"refs/heads/feature","displayId":"feature","repository":{"slug":"repo","project":{"key":"PROJ"}}},
+			"toRef":{"id":"refs/heads/main","displayId":"main","repository":{"slug":"repo","project":{"key":"PROJ"}}}}}`
+)
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(srv *Server, eventKey, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/bitbucket", strings.NewReader(body))
+	req.Header.Set("X-Event-Key", eventKey)
+	req.Header.Set("X-Hub-Signature", signature)
+	rec := httptest.NewRecorder()
+	srv.WebhookHandler(webhookSecret).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestValidSignature(t *testing.T) {
+	body := []byte(`{"a":1}`)
+	if !validSignature(webhookSecret, body, sign(string(body))) {
+		t.Error("valid signature rejected")
+	}
+	for _, header := range []string{"", "sha256=zz", "sha1=" + strings.TrimPrefix(sign(string(body)), "sha256="), sign(`{"a":2}`)} {
+		if validSignature(webhookSecret, body, header) {
+			t.Errorf("signature %q accepted", header)
+		}
+	}
+	if validSignature("", body, sign(string(body))) {
+		t.Error("empty secret must reject every delivery")
+	}
+}
+
+func TestNormalizeEvent_PullRequest(t *testing.T) {
+	var p webhookPayload
+	if err := json.Unmarshal([]byte(prOpenedJSON), &p); err != nil {
+		t.Fatal(err)
+	}
+	ev := normalizeEvent("", p)
+	if ev.Event != "pr:opened" || ev.Actor != "alice" || ev.PullRequestID != 42 || ev.FromBranch != "feature" || ev.ToBranch != "main" {
+		t.Errorf("ev = %+v", ev)
+	}
+	if ev.Project != "PROJ" || ev.Repository != "repo" || ev.URI != "bitbucket://events/PROJ/repo" {
+		t.Errorf("ev location = %+v", ev)
+	}
+}
+
+func TestNormalizeEvent_CommentAndRefs(t *testing.T) {
+	var p webhookPayload
+	_ = json.Unmarshal([]byte(`{"eventKey":"pr:comment:added","pullRequest":{"id":1},"comment":{"id":9,"text":"LGTM"}}`), &p)
+	ev := normalizeEvent("pr:comment:added", p)
+	if ev.CommentID != 9 || ev.Comment != "LGTM" || ev.URI != eventsURI {
+		t.Errorf("comment ev = %+v", ev)
+	}
+
+	p = webhookPayload{}
+	_ = json.Unmarshal([]byte(`{"repository":{"slug":"repo","project":{"key":"PROJ"}},
+		"changes":[{"ref":{"displayId":"main"},"refId":"refs/heads/main","fromHash":"a","toHash":"b","type":"UPDATE"},
+		{"refId":"refs/tags/v1","toHash":"c","type":"ADD"}]}`), &p)
+	ev = normalizeEvent("repo:refs_changed", p)
+	if len(ev.Changes) != 2 || ev.Changes[0].Ref != "main" || ev.Changes[1].Ref != "refs/tags/v1" || ev.Changes[1].Type != "ADD" {
+		t.Errorf("refs ev = %+v", ev)
+	}
+	if ev.URI != "bitbucket://events/PROJ/repo" {
+		t.Errorf("URI = %q", ev.URI)
+	}
+}
+
+func TestEventLog(t *testing.T) {
+	var l eventLog
+	for i := range maxRecentEvents + 5 {
+		uri := repoEventsURI("PROJ", "a")
+		if i%2 == 1 {
+			uri = repoEventsURI("PROJ", "ab")
+		}
+		l.add(WebhookEvent{Event: "pr:opened", PullRequestID: i, URI: uri})
+	}
+	all := l.recent(eventsURI)
+	if len(all) != maxRecentEvents || all[0].PullRequestID != maxRecentEvents+4 {
+		t.Errorf("len = %d, newest = %d", len(all), all[0].PullRequestID)
+	}
+	for _, ev := range l.recent(repoEventsURI("PROJ", "a")) {
+		if ev.URI != repoEventsURI("PROJ", "a") {
+			t.Fatalf("unexpected event for %s", ev.URI)
+		}
+	}
+	if got := l.recent(repoEventsURI("OTHER", "x")); got == nil || len(got) != 0 {
+		t.Errorf("recent(other) = %v, want empty slice", got)
+	}
+}
+
+func TestWebhookHandler_Rejections(t *testing.T) {
+	srv := NewServer(bitbucket.NewClient("https://bb.example.com", nil, "off"), "")
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/bitbucket", nil)
+	rec := httptest.NewRecorder()
+	srv.WebhookHandler(webhookSecret).ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: code = %d", rec.Code)
+	}
+	if rec := deliver(srv, "pr:opened", prOpenedJSON, "sha256=00"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad signature: code = %d", rec.Code)
+	}
+	if rec := deliver(srv, "pr:opened", "{", sign("{")); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad JSON: code = %d", rec.Code)
+	}
+	if rec := deliver(srv, "", "{}", sign("{}")); rec.Code != http.StatusBadRequest {
+		t.Errorf("no event key: code = %d", rec.Code)
+	}
+	big := strings.Repeat(" ", maxWebhookBody+1)
+	if rec := deliver(srv, "pr:opened", big, sign(big)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized: code = %d", rec.Code)
+	}
+	if rec := deliver(srv, "diagnostics:ping", `{"test":true}`, sign(`{"test":true}`)); rec.Code != http.StatusNoContent {
+		t.Errorf("ping: code = %d", rec.Code)
+	}
+	if n := len(srv.events.recent(eventsURI)); n != 0 {
+		t.Errorf("stored %d events, want 0", n)
+	}
+}
+
+// eventAccessMux lets the test credentials read PROJ/repo but not PROJ/secret.
+func eventAccessMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","project":{"key":"PROJ"}}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/secret", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	return mux
+}
+
+func TestWebhookHandler_NotifiesSessions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, ts := bbServer(eventAccessMux())
+	defer ts.Close()
+
+	updated := make(chan string, 4)
+	logged := make(chan any, 4)
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test"}, &sdkmcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *sdkmcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+		LoggingMessageHandler: func(_ context.Context, req *sdkmcp.LoggingMessageRequest) {
+			logged <- req.Params.Data
+		},
+	})
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := srv.mcpServer.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if err := session.Subscribe(ctx, &sdkmcp.SubscribeParams{URI: "bitbucket://events/PROJ/repo"}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := session.SetLoggingLevel(ctx, &sdkmcp.SetLoggingLevelParams{Level: "info"}); err != nil {
+		t.Fatalf("SetLoggingLevel: %v", err)
+	}
+
+	if rec := deliver(srv, "pr:opened", prOpenedJSON, sign(prOpenedJSON)); rec.Code != http.StatusNoContent {
+		t.Fatalf("code = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	select {
+	case uri := <-updated:
+		if uri != "bitbucket://events/PROJ/repo" {
+			t.Errorf("updated URI = %q", uri)
+		}
+	case <-ctx.Done():
+		t.Fatal("no resource update notification")
+	}
+	select {
+	case data := <-logged:
+		if m, ok := data.(map[string]any); !ok || m["event"] != "pr:opened" {
+			t.Errorf("log data = %v", data)
+		}
+	case <-ctx.Done():
+		t.Fatal("no log notification")
+	}
+
+	res, err := session.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: "bitbucket://events/PROJ/repo"})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if !strings.Contains(res.Contents[0].Text, `"pullRequestId":42`) {
+		t.Errorf("contents = %s", res.Contents[0].Text)
+	}
+	res, err = session.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: eventsURI})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if !strings.Contains(res.Contents[0].Text, `"event":"pr:opened"`) {
+		t.Errorf("contents = %s", res.Contents[0].Text)
+	}
+}
+
+func TestWebhookHandler_FiltersByRepositoryAccess(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, ts := bbServer(eventAccessMux())
+	defer ts.Close()
+
+	logged := make(chan any, 4)
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test"}, &sdkmcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *sdkmcp.LoggingMessageRequest) {
+			logged <- req.Params.Data
+		},
+	})
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := srv.mcpServer.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if err := session.SetLoggingLevel(ctx, &sdkmcp.SetLoggingLevelParams{Level: "info"}); err != nil {
+		t.Fatalf("SetLoggingLevel: %v", err)
+	}
+	if err := session.Subscribe(ctx, &sdkmcp.SubscribeParams{URI: "bitbucket://events/PROJ/secret"}); err == nil {
+		t.Error("subscribed to an unreadable repository")
+	}
+
+	// Logging alone, without a subscription, receives nothing.
+	if rec := deliver(srv, "pr:opened", prOpenedJSON, sign(prOpenedJSON)); rec.Code != http.StatusNoContent {
+		t.Fatalf("code = %d", rec.Code)
+	}
+	srv.notifying.Wait()
+	if err := session.Subscribe(ctx, &sdkmcp.SubscribeParams{URI: eventsURI}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	secret := strings.ReplaceAll(prOpenedJSON, `"slug":"repo"`, `"slug":"secret"`)
+	if rec := deliver(srv, "pr:opened", secret, sign(secret)); rec.Code != http.StatusNoContent {
+		t.Fatalf("code = %d", rec.Code)
+	}
+	if rec := deliver(srv, "pr:opened", prOpenedJSON, sign(prOpenedJSON)); rec.Code != http.StatusNoContent {
+		t.Fatalf("code = %d", rec.Code)
+	}
+	select {
+	case data := <-logged:
+		if m, ok := data.(map[string]any); !ok || m["repository"] != "repo" {
+			t.Errorf("log data = %v, want only the readable repository's event", data)
+		}
+	case <-ctx.Done():
+		t.Fatal("no log notification")
+	}
+	select {
+	case data := <-logged:
+		t.Errorf("unexpected log: %v", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	res, err := session.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: eventsURI})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if strings.Contains(res.Contents[0].Text, "secret") || strings.Count(res.Contents[0].Text, `"pullRequestId"`) != 2 {
+		t.Errorf("contents = %s", res.Contents[0].Text)
+	}
+}
+
+func TestWebhookHandler_RespondsBeforeAccessCheck(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","project":{"key":"PROJ"}}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	logged := make(chan any, 4)
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test"}, &sdkmcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *sdkmcp.LoggingMessageRequest) {
+			logged <- req.Params.Data
+		},
+	})
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := srv.mcpServer.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if err := session.SetLoggingLevel(ctx, &sdkmcp.SetLoggingLevelParams{Level: "info"}); err != nil {
+		t.Fatalf("SetLoggingLevel: %v", err)
+	}
+	if err := session.Subscribe(ctx, &sdkmcp.SubscribeParams{URI: eventsURI}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// The access check is still blocked on Bitbucket when the delivery is answered.
+	if rec := deliver(srv, "pr:opened", prOpenedJSON, sign(prOpenedJSON)); rec.Code != http.StatusNoContent {
+		t.Fatalf("code = %d", rec.Code)
+	}
+	if n := len(srv.events.recent(eventsURI)); n != 1 {
+		t.Errorf("stored %d events, want 1", n)
+	}
+	close(release)
+	select {
+	case data := <-logged:
+		if m, ok := data.(map[string]any); !ok || m["repository"] != "repo" {
+			t.Errorf("log data = %v", data)
+		}
+	case <-ctx.Done():
+		t.Fatal("no log notification")
+	}
+	srv.notifying.Wait()
+}
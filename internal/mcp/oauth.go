@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OAuth 2.0 provider endpoints of Bitbucket Data Center 8.x (incoming application links).
+const (
+	bitbucketAuthorizePath = "/rest/oauth2/latest/authorize"
+	bitbucketTokenPath     = "/rest/oauth2/latest/token"
+)
+
+// Paths of the OAuth endpoints this server adds in front of Bitbucket.
+const (
+	OAuthMetadataPath = "/.well-known/oauth-authorization-server"
+	OAuthRegisterPath = "/oauth/register"
+	OAuthTokenPath    = "/oauth/token"
+)
+
+const (
+	maxOAuthRequestBody  = 64 << 10
+	maxOAuthResponseBody = 1 << 20
+)
+
+// bitbucketOAuthScopes are the scopes Bitbucket's OAuth 2.0 provider understands.
+var bitbucketOAuthScopes = []string{
+	"PUBLIC_REPOS", "REPO_READ", "REPO_WRITE", "REPO_ADMIN",
+	"PROJECT_ADMIN", "ACCOUNT_WRITE", "ADMIN_WRITE", "SYSTEM_ADMIN",
+}
+
+var oauthHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// OAuthConfig describes the Bitbucket application link MCP clients sign in through.
+// Bitbucket publishes no RFC 8414 metadata and has no dynamic client registration,
+// so this server provides both, with itself as the issuer.
+type OAuthConfig struct {
+	PublicURL    string // base URL of this server, the issuer MCP clients see
+	BitbucketURL string
+	ClientID     string
+	// ClientSecret, when set, stays on the server: token requests go through
+	// OAuthTokenPath, which adds it before forwarding to Bitbucket.
+	ClientSecret string
+	RedirectURL  string            // the redirect URL configured on the application link
+	Headers      map[string]string // extra headers for requests to Bitbucket
+}
+
+func (c OAuthConfig) tokenEndpoint() string {
+	if c.ClientSecret != "" {
+		return c.PublicURL + OAuthTokenPath
+	}
+	return c.BitbucketURL + bitbucketTokenPath
+}
+
+// AuthorizationServerMetadata is the OAuth 2.0 Authorization Server Metadata (RFC 8414).
+type AuthorizationServerMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	RegistrationEndpoint              string   `json:"registration_endpoint,omitempty"`
+	ScopesSupported                   []string `json:"scopes_supported,omitempty"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported,omitempty"`
+}
+
+// AuthorizationServerMetadataHandler serves RFC 8414 metadata at OAuthMetadataPath that
+// sends users to Bitbucket's authorize endpoint under /rest/oauth2/latest.
+func AuthorizationServerMetadataHandler(cfg OAuthConfig) http.Handler {
+	meta := AuthorizationServerMetadata{
+		Issuer:                            cfg.PublicURL,
+		AuthorizationEndpoint:             cfg.BitbucketURL + bitbucketAuthorizePath,
+		TokenEndpoint:                     cfg.tokenEndpoint(),
+		RegistrationEndpoint:              cfg.PublicURL + OAuthRegisterPath,
+		ScopesSupported:                   bitbucketOAuthScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token"},
+		TokenEndpointAuthMethodsSupported: []string{"none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+	body, _ := json.Marshal(meta)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_, _ = w.Write(body)
+	})
+}
+
+// clientRegistration is the subset of RFC 7591 client metadata MCP clients send.
+type clientRegistration struct {
+	ClientID                string   `json:"client_id,omitempty"`
+	ClientIDIssuedAt        int64    `json:"client_id_issued_at,omitempty"`
+	ClientName              string   `json:"client_name,omitempty"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// ClientRegistrationHandler implements RFC 7591 dynamic client registration by handing
+// every client the configured client id as a public client. Bitbucket only accepts the
+// redirect URL configured on its application link, so clients asking for any other
+// redirect URI are refused here rather than failing later at Bitbucket's authorize page.
+func ClientRegistrationHandler(cfg OAuthConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var reg clientRegistration
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxOAuthRequestBody)).Decode(&reg); err != nil {
+			oauthError(w, http.StatusBadRequest, "invalid_client_metadata", "body must be JSON client metadata")
+			return
+		}
+		if len(reg.RedirectURIs) == 0 {
+			oauthError(w, http.StatusBadRequest, "invalid_redirect_uri", "redirect_uris is required")
+			return
+		}
+		for _, uri := range reg.RedirectURIs {
+			if uri != cfg.RedirectURL {
+				oauthError(w, http.StatusBadRequest, "invalid_redirect_uri", "the only redirect URI allowed is "+cfg.RedirectURL)
+				return
+			}
+		}
+		reg.ClientID = cfg.ClientID
+		reg.ClientIDIssuedAt = time.Now().Unix()
+		reg.GrantTypes = []string{"authorization_code", "refresh_token"}
+		reg.ResponseTypes = []string{"code"}
+		reg.TokenEndpointAuthMethod = "none"
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(reg)
+	})
+}
+
+// TokenProxyHandler forwards token requests to Bitbucket with the configured client
+// credentials, so MCP clients can act as public clients of a confidential application link.
+func TokenProxyHandler(cfg OAuthConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxOAuthRequestBody)
+		if err := r.ParseForm(); err != nil {
+			oauthError(w, http.StatusBadRequest, "invalid_request", "body must be form-encoded")
+			return
+		}
+		form := r.PostForm
+		if g := form.Get("grant_type"); g != "authorization_code" && g != "refresh_token" {
+			oauthError(w, http.StatusBadRequest, "unsupported_grant_type", "use authorization_code or refresh_token")
+			return
+		}
+		// Clients are public, so PKCE is what ties a code to the client that asked for it.
+		if form.Get("grant_type") == "authorization_code" && form.Get("code_verifier") == "" {
+			oauthError(w, http.StatusBadRequest, "invalid_request", "code_verifier is required (PKCE)")
+			return
+		}
+		if id := form.Get("client_id"); id != "" && id != cfg.ClientID {
+			oauthError(w, http.StatusUnauthorized, "invalid_client", "unknown client_id")
+			return
+		}
+		form.Set("client_id", cfg.ClientID)
+		form.Set("client_secret", cfg.ClientSecret)
+
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, cfg.BitbucketURL+bitbucketTokenPath, strings.NewReader(form.Encode()))
+		if err != nil {
+			oauthError(w, http.StatusInternalServerError, "server_error", err.Error())
+			return
+		}
+		for k, v := range cfg.Headers {
+			req.Header.Set(k, v)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		resp, err := oauthHTTPClient.Do(req)
+		if err != nil {
+			oauthError(w, http.StatusBadGateway, "server_error", "bitbucket token endpoint unreachable")
+			return
+		}
+		defer resp.Body.Close()
+		w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, io.LimitReader(resp.Body, maxOAuthResponseBody))
+	})
+}
+
+// oauthError writes an RFC 6749 error response.
+func oauthError(w http.ResponseWriter, status int, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+}
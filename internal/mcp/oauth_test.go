@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func testOAuthConfig(secret string) OAuthConfig {
+	return OAuthConfig{
+		PublicURL:    "https://mcp.example.com",
+		BitbucketURL: "https://bitbucket.example.com",
+		ClientID:     "client-1",
+		ClientSecret: secret,
+		RedirectURL:  "http://127.0.0.1:33418/",
+	}
+}
+
+func TestAuthorizationServerMetadataHandler(t *testing.T) {
+	for secret, tokenEndpoint := range map[string]string{
+		"":       "https://bitbucket.example.com/rest/oauth2/latest/token",
+		"s3cret": "https://mcp.example.com/oauth/token",
+	} {
+		rec := httptest.NewRecorder()
+		AuthorizationServerMetadataHandler(testOAuthConfig(secret)).ServeHTTP(rec, httptest.NewRequest("GET", OAuthMetadataPath, nil))
+		var meta AuthorizationServerMetadata
+		if err := json.NewDecoder(rec.Body).Decode(&meta); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		if meta.Issuer != "https://mcp.example.com" || meta.AuthorizationEndpoint != "https://bitbucket.example.com/rest/oauth2/latest/authorize" {
+			t.Errorf("meta = %+v", meta)
+		}
+		if meta.TokenEndpoint != tokenEndpoint || meta.RegistrationEndpoint != "https://mcp.example.com/oauth/register" {
+			t.Errorf("secret %q: token = %q, registration = %q", secret, meta.TokenEndpoint, meta.RegistrationEndpoint)
+		}
+		if len(meta.CodeChallengeMethodsSupported) != 1 || meta.CodeChallengeMethodsSupported[0] != "S256" {
+			t.Errorf("code_challenge_methods_supported = %v", meta.CodeChallengeMethodsSupported)
+		}
+	}
+}
+
+func TestClientRegistrationHandler(t *testing.T) {
+	h := ClientRegistrationHandler(testOAuthConfig(""))
+	body := `{"client_name":"VS Code","redirect_uris":["http://127.0.0.1:33418/"],"token_endpoint_auth_method":"client_secret_basic"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", OAuthRegisterPath, strings.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Code = %d: %s", rec.Code, rec.Body)
+	}
+	var reg clientRegistration
+	if err := json.NewDecoder(rec.Body).Decode(&reg); err != nil {
+		t.Fatal(err)
+	}
+	if reg.ClientID != "client-1" || reg.ClientName != "VS Code" || reg.TokenEndpointAuthMethod != "none" || len(reg.RedirectURIs) != 1 {
+		t.Errorf("registration = %+v", reg)
+	}
+
+	for _, body := range []string{
+		`not json`,
+		`{"client_name":"x"}`,
+		`{"redirect_uris":["https://evil.example.com/cb"]}`,
+		`{"redirect_uris":["http://127.0.0.1:33418/","https://evil.example.com/cb"]}`,
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", OAuthRegisterPath, strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: Code = %d", body, rec.Code)
+		}
+	}
+}
+
+func TestTokenProxyHandler(t *testing.T) {
+	bb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/oauth2/latest/token" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_ = r.ParseForm()
+		if r.PostForm.Get("client_id") != "client-1" || r.PostForm.Get("client_secret") != "s3cret" || r.PostForm.Get("code_verifier") != "v" {
+			t.Errorf("form = %v", r.PostForm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","token_type":"bearer"}`))
+	}))
+	defer bb.Close()
+	cfg := testOAuthConfig("s3cret")
+	cfg.BitbucketURL = bb.URL
+	h := TokenProxyHandler(cfg)
+
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", OAuthTokenPath, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	rec := post(url.Values{"grant_type": {"authorization_code"}, "code": {"c"}, "code_verifier": {"v"}, "client_id": {"client-1"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"access_token":"at"`) || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Code = %d, body = %s", rec.Code, rec.Body)
+	}
+	if rec := post(url.Values{"grant_type": {"authorization_code"}, "code": {"c"}, "client_id": {"client-1"}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("no code_verifier: Code = %d", rec.Code)
+	}
+	if rec := post(url.Values{"grant_type": {"password"}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("password grant: Code = %d", rec.Code)
+	}
+	if rec := post(url.Values{"grant_type": {"refresh_token"}, "client_id": {"other"}}); rec.Code != http.StatusUnauthorized {
+		t.Errorf("foreign client: Code = %d", rec.Code)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 
 	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -17,16 +18,27 @@ type Server struct {
 	mcpServer        *mcp.Server
 	client           *bitbucket.Client
 	defaultProjectKey string
+	events           eventLog
+	subscribers      eventSubscribers
+	access           repoAccessCache
+	notifying        sync.WaitGroup // webhook notification fan-outs still running
+	token            string // Bitbucket token for requests without TokenInfo (stdio transport)
 }
 
 // NewServer creates an MCP server with Bitbucket tools.
 func NewServer(client *bitbucket.Client, defaultProjectKey string) *Server {
 	s := &Server{
-		mcpServer:        mcp.NewServer(&mcp.Implementation{Name: "bitbucket-mcp", Version: "1.0.0"}, nil),
 		client:           client,
 		defaultProjectKey: defaultProjectKey,
 	}
+	// The SDK tracks subscriptions for ResourceUpdated; subscribeEvents also records each
+	// session's credentials so events are only shown to users who can read the repository.
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: "bitbucket-mcp", Version: "1.0.0"}, &mcp.ServerOptions{
+		SubscribeHandler:   s.subscribeEvents,
+		UnsubscribeHandler: s.unsubscribeEvents,
+	})
 	s.registerTools()
+	s.registerEventResources()
 	return s
 }
 
@@ -42,6 +54,19 @@ func (s *Server) registerTools() {
 	s.registerPRTools()
 	s.registerRepoTools()
 	s.registerBranchTools()
+	s.registerProjectTools()
+	s.registerPermissionTools()
+	s.registerUserTools()
+	s.registerBuildTools()
+	s.registerInsightTools()
+	s.registerWebhookTools()
+	s.registerRestrictionTools()
+	s.registerReviewerTools()
+	s.registerSettingsTools()
+	s.registerCommentTools()
+	s.registerEditFilesTools()
+	s.registerSearchTools()
+	s.registerJiraTools()
 }
 
 func (s *Server) projectKey(slug string) string {
@@ -52,11 +77,19 @@ func (s *Server) projectKey(slug string) string {
 }
 
 func (s *Server) getOpts(ctx context.Context, req *mcp.CallToolRequest) bitbucket.RequestOpts {
-	token := ""
-	if req.Extra != nil && req.Extra.TokenInfo != nil {
-		token = req.Extra.TokenInfo.UserID // raw Bearer token from passthrough verifier
+	return s.extraOpts(ctx, req.Extra)
+}
+
+// extraOpts builds the Bitbucket credentials of any MCP request from its transport extras.
+func (s *Server) extraOpts(ctx context.Context, extra *mcp.RequestExtra) bitbucket.RequestOpts {
+	token, username := s.token, ""
+	if extra != nil && extra.TokenInfo != nil {
+		token = auth.BitbucketToken(extra.TokenInfo) // client's Bearer token, or the service account's
+		username = auth.BitbucketUsername(extra.TokenInfo)
 	}
-	return bitbucket.RequestOptsFromContext(ctx, token)
+	opts := bitbucket.RequestOptsFromContext(ctx, token)
+	opts.Username = username
+	return opts
 }
 
 func (s *Server) listWorkspaces(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
@@ -96,13 +129,25 @@ func (s *Server) Handler() http.Handler {
 	}, nil)
 }
 
-// AuthMiddleware returns the auth middleware (RequireBearerToken with passthrough verifier).
+// RunStdio serves MCP over stdin/stdout until the client disconnects or ctx is done.
+// Stdio requests carry no Authorization header, so every Bitbucket call uses token.
+func (s *Server) RunStdio(ctx context.Context, token string) error {
+	return s.run(ctx, token, &mcp.StdioTransport{})
+}
+
+func (s *Server) run(ctx context.Context, token string, t mcp.Transport) error {
+	s.token = token
+	return s.mcpServer.Run(ctx, t)
+}
+
+// AuthMiddleware returns the auth middleware (RequireBearerToken with the given verifier,
+// e.g. auth.PassthroughVerifier or auth.ServiceAccountVerifier).
 // resourceMetadataURL is the full URL to Protected Resource Metadata (RFC 9728), e.g. https://mcp.example.com/.well-known/oauth-protected-resource/mcp.
 // If empty, WWW-Authenticate on 401 will not include resource_metadata.
-func AuthMiddleware(resourceMetadataURL string) func(http.Handler) http.Handler {
+func AuthMiddleware(resourceMetadataURL string, verifier sdkauth.TokenVerifier) func(http.Handler) http.Handler {
 	opts := &sdkauth.RequireBearerTokenOptions{
 		ResourceMetadataURL: resourceMetadataURL,
 		Scopes:              []string{"REPO_READ", "REPO_WRITE"},
 	}
-	return sdkauth.RequireBearerToken(auth.PassthroughVerifier(), opts)
+	return sdkauth.RequireBearerToken(verifier, opts)
 }
@@ -9,6 +9,7 @@ import (
 
 	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/auth"
 	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
 )
 
@@ -89,6 +90,73 @@ func TestGetOpts_NilTokenInfo(t *testing.T) {
 	}
 }
 
+func TestGetOpts_ServiceAccount(t *testing.T) {
+	srv := &Server{}
+	info, err := auth.ServiceAccountVerifier("service-pat", map[string]string{"n8n": "key"})(context.Background(), "key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &sdkmcp.CallToolRequest{Extra: &sdkmcp.RequestExtra{TokenInfo: info}}
+	if opts := srv.getOpts(context.Background(), req); opts.Token != "service-pat" {
+		t.Errorf("Token = %q, want service-pat", opts.Token)
+	}
+}
+
+func TestGetOpts_BasicAuth(t *testing.T) {
+	srv := &Server{}
+	req := &sdkmcp.CallToolRequest{Extra: &sdkmcp.RequestExtra{TokenInfo: &sdkauth.TokenInfo{
+		UserID: "svc",
+		Extra:  map[string]any{auth.ExtraBitbucketUser: "svc", auth.ExtraBitbucketToken: "pw"},
+	}}}
+	if opts := srv.getOpts(context.Background(), req); opts.Username != "svc" || opts.Token != "pw" {
+		t.Errorf("opts = %+v", opts)
+	}
+}
+
+func TestGetOpts_StaticToken(t *testing.T) {
+	srv := &Server{token: "env-token"}
+	if opts := srv.getOpts(context.Background(), &sdkmcp.CallToolRequest{}); opts.Token != "env-token" {
+		t.Errorf("Token = %q, want env-token", opts.Token)
+	}
+	req := &sdkmcp.CallToolRequest{Extra: &sdkmcp.RequestExtra{TokenInfo: &sdkauth.TokenInfo{UserID: "bearer"}}}
+	if opts := srv.getOpts(context.Background(), req); opts.Token != "bearer" {
+		t.Errorf("Token = %q, want bearer", opts.Token)
+	}
+}
+
+func TestRun_UsesToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/jdoe", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer env-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"jdoe","slug":"jdoe"}`))
+	})
+	client, ts := newTestBitbucket(mux)
+	defer ts.Close()
+	srv := NewServer(client, "PROJ")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serverT, clientT := sdkmcp.NewInMemoryTransports()
+	errc := make(chan error, 1)
+	go func() { errc <- srv.run(ctx, "env-token", serverT) }()
+
+	session, err := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test"}, nil).Connect(ctx, clientT, nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	result, err := session.CallTool(ctx, &sdkmcp.CallToolParams{Name: "bitbucket_get_user", Arguments: map[string]any{"userSlug": "jdoe"}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool = %+v, %v", result, err)
+	}
+	_ = session.Close()
+	if err := <-errc; err != nil {
+		t.Errorf("run: %v", err)
+	}
+}
+
 func TestHandler(t *testing.T) {
 	client := bitbucket.NewClient("https://bb.example.com", nil, "off")
 	srv := NewServer(client, "PROJ")
@@ -113,14 +181,14 @@ func TestHandler_HTTPRequest(t *testing.T) {
 }
 
 func TestAuthMiddleware(t *testing.T) {
-	mw := AuthMiddleware("")
+	mw := AuthMiddleware("", auth.PassthroughVerifier())
 	if mw == nil {
 		t.Error("AuthMiddleware should not be nil")
 	}
 }
 
 func TestAuthMiddleware_WithResourceMetadataURL(t *testing.T) {
-	mw := AuthMiddleware("https://mcp.example.com/.well-known/oauth-protected-resource/mcp")
+	mw := AuthMiddleware("https://mcp.example.com/.well-known/oauth-protected-resource/mcp", auth.PassthroughVerifier())
 	if mw == nil {
 		t.Error("AuthMiddleware should not be nil")
 	}
@@ -134,6 +202,37 @@ func TestAuthMiddleware_WithResourceMetadataURL(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_BitbucketVerifier(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/current", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"jdoe"}`))
+	})
+	client, ts := newTestBitbucket(mux)
+	defer ts.Close()
+
+	var user string
+	h := AuthMiddleware("", auth.BitbucketVerifier(client, time.Minute, time.Minute))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = sdkauth.TokenInfoFromContext(r.Context()).UserID
+	}))
+	for token, want := range map[string]int{"good": http.StatusOK, "bad": http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s token: status = %d, want %d", token, rec.Code, want)
+		}
+	}
+	if user != "jdoe" {
+		t.Errorf("UserID = %q, want jdoe", user)
+	}
+}
+
 func TestListWorkspaces(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerBuildTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_commit_build_status",
+		Description: "Get CI build results for a commit with an overall state (SUCCESSFUL, FAILED, INPROGRESS), or the one build with a given key",
+	}, s.getCommitBuildStatus)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_post_build_status",
+		Description: "Record a CI build result (key, state, url, description, duration) on a commit",
+	}, s.postBuildStatus)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_pull_request_build_status",
+		Description: "Summarize CI build results for a pull request's latest source commit",
+	}, s.getPullRequestBuildStatus)
+}
+
+// maxBuildPages bounds how many pages of build statuses a summary reads.
+const maxBuildPages = 10
+
+// buildSummary is the result of the build status tools.
+type buildSummary struct {
+	CommitID string                  `json:"commitId"`
+	State    string                  `json:"state"` // overall state; NONE when no builds are reported
+	Builds   []bitbucket.BuildStatus `json:"builds"`
+	PRID     int                     `json:"prId,omitempty"`
+	Branch   string                  `json:"branch,omitempty"`
+	// Truncated reports that more than maxBuildPages pages of builds exist; State is
+	// then never SUCCESSFUL, since an unread build may have failed.
+	Truncated bool `json:"truncated"`
+}
+
+func (s *Server) buildSummary(ctx context.Context, commitID string, opts bitbucket.RequestOpts) (*buildSummary, error) {
+	summary := &buildSummary{CommitID: commitID, Builds: []bitbucket.BuildStatus{}}
+	for start, page := 0, 0; ; page++ {
+		resp, err := s.client.ListBuildStatuses(ctx, commitID, start, opts)
+		if err != nil {
+			return nil, err
+		}
+		summary.Builds = append(summary.Builds, resp.Values...)
+		if resp.IsLastPage || resp.NextPageStart <= start {
+			break
+		}
+		if page+1 == maxBuildPages {
+			summary.Truncated = true
+			break
+		}
+		start = resp.NextPageStart
+	}
+	summary.State = bitbucket.OverallBuildState(summary.Builds)
+	switch {
+	case summary.State == "":
+		summary.State = "NONE"
+	case summary.Truncated && summary.State == bitbucket.BuildSuccessful:
+		summary.State = bitbucket.BuildUnknown
+	}
+	return summary, nil
+}
+
+type getCommitBuildStatusArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT); used with key"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; required with key"`
+	CommitID      string `json:"commitId" jsonschema:"required,Full commit hash"`
+	Key           string `json:"key" jsonschema:"Only return the build with this key (Bitbucket 7.14+)"`
+}
+
+func (s *Server) getCommitBuildStatus(ctx context.Context, req *mcp.CallToolRequest, args getCommitBuildStatusArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	var summary *buildSummary
+	if args.Key != "" {
+		projectKey := s.projectKey(args.WorkspaceSlug)
+		if projectKey == "" || args.Repository == "" {
+			return nil, nil, fmt.Errorf("workspaceSlug and repository required with key (or set BITBUCKET_DEFAULT_PROJECT)")
+		}
+		build, err := s.client.GetBuildStatus(ctx, projectKey, args.Repository, args.CommitID, args.Key, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary = &buildSummary{CommitID: args.CommitID, State: build.State, Builds: []bitbucket.BuildStatus{*build}}
+	} else {
+		var err error
+		if summary, err = s.buildSummary(ctx, args.CommitID, opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type postBuildStatusArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT); with repository uses the repository-scoped API"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit to use the legacy commit-only API"`
+	CommitID      string `json:"commitId" jsonschema:"required,Full commit hash"`
+	Key           string `json:"key" jsonschema:"required,Unique build key (e.g. the CI job name)"`
+	State         string `json:"state" jsonschema:"required,SUCCESSFUL, FAILED, INPROGRESS, CANCELLED or UNKNOWN"`
+	URL           string `json:"url" jsonschema:"required,Link to the build"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	BuildNumber   string `json:"buildNumber"`
+	Ref           string `json:"ref" jsonschema:"Ref that was built (e.g. refs/heads/main)"`
+	Duration      int64  `json:"duration" jsonschema:"Build duration in milliseconds"`
+}
+
+func (s *Server) postBuildStatus(ctx context.Context, req *mcp.CallToolRequest, args postBuildStatusArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := ""
+	if args.Repository != "" {
+		projectKey = s.projectKey(args.WorkspaceSlug)
+		if projectKey == "" {
+			return nil, nil, fmt.Errorf("workspaceSlug required with repository (or set BITBUCKET_DEFAULT_PROJECT)")
+		}
+	}
+	opts := s.getOpts(ctx, req)
+	err := s.client.PostBuildStatus(ctx, projectKey, args.Repository, args.CommitID, bitbucket.BuildStatus{
+		Key:         args.Key,
+		State:       args.State,
+		URL:         args.URL,
+		Name:        args.Name,
+		Description: args.Description,
+		BuildNumber: args.BuildNumber,
+		Ref:         args.Ref,
+		Duration:    args.Duration,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "build status recorded"}}}, nil, nil
+}
+
+type getPRBuildStatusArgs struct {
+	Repository    string `json:"repository" jsonschema:"required"`
+	PrID          int    `json:"prId" jsonschema:"required"`
+	WorkspaceSlug string `json:"workspaceSlug"`
+}
+
+func (s *Server) getPullRequestBuildStatus(ctx context.Context, req *mcp.CallToolRequest, args getPRBuildStatusArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required")
+	}
+	pr, err := s.client.GetPullRequest(ctx, projectKey, args.Repository, args.PrID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr.FromRef == nil || pr.FromRef.LatestCommit == "" {
+		return nil, nil, fmt.Errorf("pull request %d has no source commit", args.PrID)
+	}
+	summary, err := s.buildSummary(ctx, pr.FromRef.LatestCommit, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary.PRID = pr.ID
+	summary.Branch = pr.FromRef.DisplayID
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func TestGetCommitBuildStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"key":"ci","state":"SUCCESSFUL","url":"u"},{"key":"lint","state":"INPROGRESS","url":"u"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getCommitBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getCommitBuildStatusArgs{CommitID: "abc"})
+	if err != nil {
+		t.Fatalf("getCommitBuildStatus: %v", err)
+	}
+	var out buildSummary
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.State != "INPROGRESS" || len(out.Builds) != 2 {
+		t.Errorf("summary = %+v", out)
+	}
+}
+
+func TestGetCommitBuildStatus_Pages(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("start") {
+		case "":
+			_, _ = w.Write([]byte(`{"values":[{"key":"ci","state":"SUCCESSFUL","url":"u"}],"isLastPage":false,"nextPageStart":1}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"values":[{"key":"e2e","state":"FAILED","url":"u"}],"isLastPage":true}`))
+		default:
+			t.Errorf("start = %q", r.URL.Query().Get("start"))
+		}
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	summary, err := srv.buildSummary(context.Background(), "abc", bitbucket.RequestOpts{})
+	if err != nil {
+		t.Fatalf("buildSummary: %v", err)
+	}
+	if summary.State != bitbucket.BuildFailed || len(summary.Builds) != 2 || summary.Truncated || calls.Load() != 2 {
+		t.Errorf("summary = %+v, calls = %d", summary, calls.Load())
+	}
+}
+
+func TestGetCommitBuildStatus_Truncated(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"values":[{"key":"ci%d","state":"SUCCESSFUL","url":"u"}],"isLastPage":false,"nextPageStart":%d}`, n, n)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	summary, err := srv.buildSummary(context.Background(), "abc", bitbucket.RequestOpts{})
+	if err != nil {
+		t.Fatalf("buildSummary: %v", err)
+	}
+	if !summary.Truncated || summary.State != bitbucket.BuildUnknown || calls.Load() != maxBuildPages {
+		t.Errorf("summary state = %s, truncated = %v, calls = %d", summary.State, summary.Truncated, calls.Load())
+	}
+}
+
+func TestGetCommitBuildStatus_Key(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc/builds", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "ci" {
+			t.Errorf("key = %q", r.URL.Query().Get("key"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"ci","state":"FAILED","url":"u"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getCommitBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getCommitBuildStatusArgs{Repository: "repo", CommitID: "abc", Key: "ci"})
+	if err != nil {
+		t.Fatalf("getCommitBuildStatus: %v", err)
+	}
+	var out buildSummary
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.State != "FAILED" || len(out.Builds) != 1 || out.Builds[0].Key != "ci" {
+		t.Errorf("summary = %+v", out)
+	}
+
+	if _, _, err := srv.getCommitBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getCommitBuildStatusArgs{CommitID: "abc", Key: "ci"}); err == nil {
+		t.Error("expected error without repository")
+	}
+}
+
+func TestGetCommitBuildStatus_NoBuilds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getCommitBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getCommitBuildStatusArgs{CommitID: "abc"})
+	if err != nil {
+		t.Fatalf("getCommitBuildStatus: %v", err)
+	}
+	var out buildSummary
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.State != "NONE" || out.Builds == nil {
+		t.Errorf("summary = %+v", out)
+	}
+}
+
+func TestGetCommitBuildStatus_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getCommitBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getCommitBuildStatusArgs{CommitID: "abc"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPostBuildStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.postBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, postBuildStatusArgs{
+		Repository: "repo", CommitID: "abc", Key: "ci", State: "SUCCESSFUL", URL: "https://ci/1", Duration: 1000,
+	})
+	if err != nil {
+		t.Fatalf("postBuildStatus: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Error("expected content")
+	}
+}
+
+func TestPostBuildStatus_Legacy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+	srv.defaultProjectKey = ""
+
+	if _, _, err := srv.postBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, postBuildStatusArgs{
+		CommitID: "abc", Key: "ci", State: "FAILED", URL: "https://ci/1",
+	}); err != nil {
+		t.Fatalf("postBuildStatus: %v", err)
+	}
+}
+
+func TestPostBuildStatus_NoWorkspace(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+	srv.defaultProjectKey = ""
+
+	if _, _, err := srv.postBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, postBuildStatusArgs{
+		Repository: "repo", CommitID: "abc", Key: "ci", State: "FAILED", URL: "u",
+	}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPostBuildStatus_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/abc/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.postBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, postBuildStatusArgs{
+		Repository: "repo", CommitID: "abc", Key: "ci", State: "BOGUS", URL: "u",
+	}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetPullRequestBuildStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"fromRef":{"id":"refs/heads/feat","displayId":"feat","latestCommit":"abc"}}`))
+	})
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"key":"ci","state":"FAILED","url":"u"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getPullRequestBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getPRBuildStatusArgs{Repository: "repo", PrID: 7})
+	if err != nil {
+		t.Fatalf("getPullRequestBuildStatus: %v", err)
+	}
+	var out buildSummary
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.State != "FAILED" || out.CommitID != "abc" || out.PRID != 7 || out.Branch != "feat" {
+		t.Errorf("summary = %+v", out)
+	}
+}
+
+func TestGetPullRequestBuildStatus_NoSourceCommit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getPullRequestBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getPRBuildStatusArgs{Repository: "repo", PrID: 7}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetPullRequestBuildStatus_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"fromRef":{"latestCommit":"abc"}}`))
+	})
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getPullRequestBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getPRBuildStatusArgs{Repository: "repo", PrID: 7}); err == nil {
+		t.Error("expected build status error")
+	}
+	if _, _, err := srv.getPullRequestBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getPRBuildStatusArgs{Repository: "repo", PrID: 8}); err == nil {
+		t.Error("expected pull request error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getPullRequestBuildStatus(context.Background(), &sdkmcp.CallToolRequest{}, getPRBuildStatusArgs{Repository: "repo", PrID: 7}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerCommentTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_commit_comments",
+		Description: "List comments (with replies) on a commit, optionally only those on one file",
+	}, s.listCommitComments)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_add_commit_comment",
+		Description: "Comment on a commit, optionally anchored to a file or a file line",
+	}, s.addCommitComment)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_reply_to_commit_comment",
+		Description: "Reply to an existing comment on a commit",
+	}, s.replyToCommitComment)
+}
+
+type listCommitCommentsArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	CommitID      string `json:"commitId" jsonschema:"required"`
+	Path          string `json:"path" jsonschema:"Only comments on this file"`
+	Start         int    `json:"start" jsonschema:"Page start (from nextPageStart)"`
+}
+
+func (s *Server) listCommitComments(ctx context.Context, req *mcp.CallToolRequest, args listCommitCommentsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	comments, err := s.client.ListCommitComments(ctx, projectKey, args.Repository, args.CommitID, args.Path, args.Start, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(comments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type addCommitCommentArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	CommitID      string `json:"commitId" jsonschema:"required"`
+	Text          string `json:"text" jsonschema:"required"`
+	Path          string `json:"path" jsonschema:"File to anchor the comment to"`
+	Line          int    `json:"line" jsonschema:"Line to anchor the comment to (requires path)"`
+	LineType      string `json:"lineType" jsonschema:"ADDED (default), REMOVED or CONTEXT"`
+	FileType      string `json:"fileType" jsonschema:"TO (default; the commit's version of the file) or FROM (the parent's)"`
+}
+
+func (s *Server) addCommitComment(ctx context.Context, req *mcp.CallToolRequest, args addCommitCommentArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.Text == "" {
+		return nil, nil, fmt.Errorf("text required")
+	}
+	comment := bitbucket.CommentRequest{Text: args.Text}
+	switch {
+	case args.Line > 0 && args.Path == "":
+		return nil, nil, fmt.Errorf("path required for a line comment")
+	case args.Line > 0:
+		comment.Anchor = &bitbucket.CommentAnchor{
+			Path:     args.Path,
+			Line:     args.Line,
+			LineType: orDefault(strings.ToUpper(args.LineType), "ADDED"),
+			FileType: orDefault(strings.ToUpper(args.FileType), "TO"),
+			DiffType: "COMMIT",
+		}
+	case args.Path != "":
+		comment.Anchor = &bitbucket.CommentAnchor{Path: args.Path, DiffType: "COMMIT"}
+	}
+	return s.postCommitComment(ctx, req, projectKey, args.Repository, args.CommitID, comment)
+}
+
+type replyCommitCommentArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	CommitID      string `json:"commitId" jsonschema:"required"`
+	CommentID     int    `json:"commentId" jsonschema:"required,ID of the comment to reply to"`
+	Text          string `json:"text" jsonschema:"required"`
+}
+
+func (s *Server) replyToCommitComment(ctx context.Context, req *mcp.CallToolRequest, args replyCommitCommentArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.Text == "" || args.CommentID == 0 {
+		return nil, nil, fmt.Errorf("commentId and text required")
+	}
+	comment := bitbucket.CommentRequest{Text: args.Text, Parent: &bitbucket.CommentParent{ID: args.CommentID}}
+	return s.postCommitComment(ctx, req, projectKey, args.Repository, args.CommitID, comment)
+}
+
+func (s *Server) postCommitComment(ctx context.Context, req *mcp.CallToolRequest, projectKey, repoSlug, commitID string, comment bitbucket.CommentRequest) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	created, err := s.client.AddCommitComment(ctx, projectKey, repoSlug, commitID, comment, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(created)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// orDefault returns s, or def when s is empty.
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const commitCommentsURL = "/rest/api/1.0/projects/PROJ/repos/repo/commits/abc/comments"
+
+// commentServer records the last posted comment and echoes a created comment.
+func commentServer(t *testing.T, got *bitbucket.CommentRequest) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"text":"first"}],"isLastPage":true}`))
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":9,"text":"ok"}`))
+	})
+	return mux
+}
+
+func TestListCommitComments(t *testing.T) {
+	srv, ts := bbServer(commentServer(t, nil))
+	defer ts.Close()
+
+	result, _, err := srv.listCommitComments(context.Background(), &sdkmcp.CallToolRequest{}, listCommitCommentsArgs{Repository: "repo", CommitID: "abc"})
+	if err != nil {
+		t.Fatalf("listCommitComments: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"text":"first"`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestListCommitComments_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listCommitComments(context.Background(), &sdkmcp.CallToolRequest{}, listCommitCommentsArgs{Repository: "repo", CommitID: "abc"}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.listCommitComments(context.Background(), &sdkmcp.CallToolRequest{}, listCommitCommentsArgs{Repository: "repo", CommitID: "abc"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestAddCommitComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       addCommitCommentArgs
+		wantAnchor *bitbucket.CommentAnchor
+	}{
+		{"general", addCommitCommentArgs{Text: "hotfix"}, nil},
+		{"file", addCommitCommentArgs{Text: "hotfix", Path: "main.go"},
+			&bitbucket.CommentAnchor{Path: "main.go", DiffType: "COMMIT"}},
+		{"line", addCommitCommentArgs{Text: "hotfix", Path: "main.go", Line: 12},
+			&bitbucket.CommentAnchor{Path: "main.go", Line: 12, LineType: "ADDED", FileType: "TO", DiffType: "COMMIT"}},
+		{"removed line", addCommitCommentArgs{Text: "hotfix", Path: "main.go", Line: 3, LineType: "removed", FileType: "from"},
+			&bitbucket.CommentAnchor{Path: "main.go", Line: 3, LineType: "REMOVED", FileType: "FROM", DiffType: "COMMIT"}},
+	}
+	for _, tt := range tests {
+		var got bitbucket.CommentRequest
+		srv, ts := bbServer(commentServer(t, &got))
+		tt.args.Repository, tt.args.CommitID = "repo", "abc"
+		if _, _, err := srv.addCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, tt.args); err != nil {
+			t.Fatalf("%s: addCommitComment: %v", tt.name, err)
+		}
+		ts.Close()
+		if (got.Anchor == nil) != (tt.wantAnchor == nil) || (got.Anchor != nil && *got.Anchor != *tt.wantAnchor) {
+			t.Errorf("%s: anchor = %+v, want %+v", tt.name, got.Anchor, tt.wantAnchor)
+		}
+	}
+}
+
+func TestAddCommitComment_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(commitCommentsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.addCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, addCommitCommentArgs{Repository: "repo", CommitID: "abc", Text: "x"}); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv.addCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, addCommitCommentArgs{Repository: "repo", CommitID: "abc"}); err == nil {
+		t.Error("expected error without text")
+	}
+	if _, _, err := srv.addCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, addCommitCommentArgs{Repository: "repo", CommitID: "abc", Text: "x", Line: 4}); err == nil {
+		t.Error("expected error for line without path")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.addCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, addCommitCommentArgs{Repository: "repo", CommitID: "abc", Text: "x"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestReplyToCommitComment(t *testing.T) {
+	var got bitbucket.CommentRequest
+	srv, ts := bbServer(commentServer(t, &got))
+	defer ts.Close()
+
+	result, _, err := srv.replyToCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, replyCommitCommentArgs{
+		Repository: "repo", CommitID: "abc", CommentID: 1, Text: "done",
+	})
+	if err != nil {
+		t.Fatalf("replyToCommitComment: %v", err)
+	}
+	if got.Parent == nil || got.Parent.ID != 1 || got.Text != "done" {
+		t.Errorf("sent = %+v", got)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"id":9`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestReplyToCommitComment_Errors(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.replyToCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, replyCommitCommentArgs{Repository: "repo", CommitID: "abc", Text: "x"}); err == nil {
+		t.Error("expected error without commentId")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.replyToCommitComment(context.Background(), &sdkmcp.CallToolRequest{}, replyCommitCommentArgs{Repository: "repo", CommitID: "abc", CommentID: 1, Text: "x"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+// minExpectedHead is the shortest expectedHead accepted, so a prefix cannot match any head.
+const minExpectedHead = 7
+
+func (s *Server) registerEditFilesTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name: "bitbucket_edit_files",
+		Description: "Create or update several files on a branch, one commit per file, e.g. to prepare a pull request. " +
+			"This is not atomic: Bitbucket Data Center has no multi-file commit, so a failure can leave some files " +
+			"committed (squash-merge the pull request for a single commit). Cannot delete files. Refuses to start if " +
+			"the branch is not at expectedHead and stops with rollback guidance if a step fails or the branch moves.",
+	}, s.editFiles)
+}
+
+type fileChange struct {
+	Action  string `json:"action" jsonschema:"required,create or update (the REST API cannot delete files)"`
+	Path    string `json:"path" jsonschema:"required,File path in the repository"`
+	Content string `json:"content" jsonschema:"Full new file content"`
+}
+
+type editFilesArgs struct {
+	WorkspaceSlug string       `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	RepoSlug      string       `json:"repoSlug" jsonschema:"required"`
+	Branch        string       `json:"branch" jsonschema:"required,Branch to commit to"`
+	Message       string       `json:"message" jsonschema:"required,Commit message"`
+	Changes       []fileChange `json:"changes" jsonschema:"required,Files to create or update, applied in order"`
+	ExpectedHead  string       `json:"expectedHead" jsonschema:"Commit the branch must point to before any change is made (the commit you read the files at); the full hash or at least its first 7 characters"`
+	SourceBranch  string       `json:"sourceBranch" jsonschema:"Create branch from this branch when it does not exist yet"`
+}
+
+// appliedChange is one file committed by bitbucket_edit_files.
+type appliedChange struct {
+	Action   string `json:"action"`
+	Path     string `json:"path"`
+	CommitID string `json:"commitId"`
+}
+
+type editFilesResult struct {
+	Branch  string          `json:"branch"`
+	Created bool            `json:"created"`
+	Base    string          `json:"base"`
+	Head    string          `json:"head"`
+	Commits []appliedChange `json:"commits"`
+}
+
+func (s *Server) editFiles(ctx context.Context, req *mcp.CallToolRequest, args editFilesArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.Branch == "" || args.Message == "" || len(args.Changes) == 0 {
+		return nil, nil, fmt.Errorf("branch, message and at least one change are required")
+	}
+	for i, ch := range args.Changes {
+		switch {
+		case ch.Path == "":
+			return nil, nil, fmt.Errorf("changes[%d]: path is required", i)
+		case ch.Action == "delete":
+			return nil, nil, fmt.Errorf("changes[%d]: deleting files is not supported by the Bitbucket Data Center REST API", i)
+		case ch.Action != "create" && ch.Action != "update":
+			return nil, nil, fmt.Errorf("changes[%d]: action must be create or update, got %q", i, ch.Action)
+		}
+	}
+	if args.ExpectedHead != "" && len(args.ExpectedHead) < minExpectedHead {
+		return nil, nil, fmt.Errorf("expectedHead must be a commit hash of at least %d characters, got %q", minExpectedHead, args.ExpectedHead)
+	}
+	opts := s.getOpts(ctx, req)
+
+	result := editFilesResult{Branch: args.Branch, Commits: []appliedChange{}}
+	branch, err := s.client.GetBranch(ctx, projectKey, args.RepoSlug, args.Branch, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if branch == nil {
+		if args.SourceBranch == "" {
+			return nil, nil, fmt.Errorf("branch %s does not exist; set sourceBranch to create it", args.Branch)
+		}
+		branch, err = s.client.GetBranch(ctx, projectKey, args.RepoSlug, args.SourceBranch, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if branch == nil {
+			return nil, nil, fmt.Errorf("source branch %s does not exist", args.SourceBranch)
+		}
+		result.Created = true
+	}
+	result.Base = branch.LatestCommit
+	if args.ExpectedHead != "" && !strings.HasPrefix(result.Base, args.ExpectedHead) {
+		return nil, nil, fmt.Errorf("branch %s is at %s, not %s: nothing was committed; re-read the files at the new head and retry",
+			branch.DisplayID, result.Base, args.ExpectedHead)
+	}
+
+	head := result.Base
+	for i, ch := range args.Changes {
+		edit := bitbucket.EditFileRequest{Content: ch.Content, Message: args.Message, Branch: args.Branch}
+		if len(args.Changes) > 1 {
+			edit.Message = fmt.Sprintf("%s (%d/%d)", args.Message, i+1, len(args.Changes))
+		}
+		if i == 0 && result.Created {
+			edit.SourceBranch = args.SourceBranch
+		}
+		if ch.Action == "update" {
+			edit.SourceCommitID = head
+		}
+		commit, err := s.client.EditFile(ctx, projectKey, args.RepoSlug, ch.Path, edit, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %s (%d of %d): %w. %s", ch.Action, ch.Path, i+1, len(args.Changes), err, rollbackHint(result))
+		}
+		result.Commits = append(result.Commits, appliedChange{Action: ch.Action, Path: ch.Path, CommitID: commit.ID})
+		result.Head = commit.ID
+		// Our commit must sit directly on the previous one; otherwise someone else
+		// pushed to the branch in between and the edits are interleaved.
+		if len(commit.Parents) == 0 || commit.Parents[0].ID != head {
+			return nil, nil, fmt.Errorf("branch %s moved while committing %s (%d of %d). %s",
+				args.Branch, ch.Path, i+1, len(args.Changes), rollbackHint(result))
+		}
+		head = commit.ID
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// rollbackHint explains how to undo the edits that were already committed.
+func rollbackHint(r editFilesResult) string {
+	if len(r.Commits) == 0 {
+		return "Nothing was committed."
+	}
+	paths := make([]string, len(r.Commits))
+	for i, c := range r.Commits {
+		paths[i] = c.Path
+	}
+	hint := fmt.Sprintf("%d file(s) were already committed to %s (%s; head %s). To roll back, ",
+		len(r.Commits), r.Branch, strings.Join(paths, ", "), r.Head)
+	if r.Created {
+		return hint + "delete branch " + r.Branch + "."
+	}
+	return hint + "reset " + r.Branch + " to " + r.Base + " or revert those commits."
+}
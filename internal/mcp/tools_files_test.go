@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeRepo emulates branch heads and the browse edit endpoint of PROJ/repo.
+type fakeRepo struct {
+	mu     sync.Mutex
+	heads  map[string]string
+	n      int
+	edits  []map[string]string // form values of each edit, in order
+	failAt int                 // 1-based edit that fails with 409; 0 never fails
+	pushAt int                 // 1-based edit before which another commit lands on the branch
+}
+
+func (f *fakeRepo) commit(branch string) (parent, id string) {
+	f.n++
+	parent, id = f.heads[branch], fmt.Sprintf("c%d", f.n)
+	f.heads[branch] = id
+	return parent, id
+}
+
+func (f *fakeRepo) mux(t *testing.T) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := r.URL.Query().Get("filterText")
+		values := []map[string]string{}
+		if head, ok := f.heads[name]; ok {
+			values = append(values, map[string]string{"id": "refs/heads/" + name, "displayId": name, "latestCommit": head})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"values": values, "isLastPage": true})
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm: %v", err)
+		}
+		form := map[string]string{"path": strings.TrimPrefix(r.URL.Path, "/rest/api/1.0/projects/PROJ/repos/repo/browse/")}
+		for k, v := range r.MultipartForm.Value {
+			form[k] = v[0]
+		}
+		f.edits = append(f.edits, form)
+		if len(f.edits) == f.failAt {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		branch := form["branch"]
+		if _, ok := f.heads[branch]; !ok {
+			f.heads[branch] = f.heads[form["sourceBranch"]]
+		}
+		if len(f.edits) == f.pushAt {
+			f.commit(branch)
+		}
+		parent, id := f.commit(branch)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":%q,"displayId":%q,"parents":[{"id":%q}]}`, id, id, parent)
+	})
+	return mux
+}
+
+// baseHead is the commit every fake branch starts at.
+const baseHead = "0123456789abcdef0123456789abcdef01234567"
+
+func editFilesTestArgs() editFilesArgs {
+	return editFilesArgs{
+		RepoSlug: "repo", Branch: "fix", Message: "Fix docs",
+		Changes: []fileChange{
+			{Action: "update", Path: "README.md", Content: "# Docs\n"},
+			{Action: "create", Path: "docs/usage.md", Content: "Usage\n"},
+		},
+	}
+}
+
+func TestEditFiles_NewBranch(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"main": baseHead}}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	args := editFilesTestArgs()
+	args.SourceBranch, args.ExpectedHead = "main", baseHead[:minExpectedHead]
+	result, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args)
+	if err != nil {
+		t.Fatalf("editFiles: %v", err)
+	}
+	var out editFilesResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Created || out.Base != baseHead || out.Head != "c2" || len(out.Commits) != 2 || out.Commits[1].Path != "docs/usage.md" {
+		t.Errorf("result = %+v", out)
+	}
+	first, second := repo.edits[0], repo.edits[1]
+	if first["sourceBranch"] != "main" || first["sourceCommitId"] != baseHead || first["message"] != "Fix docs (1/2)" {
+		t.Errorf("first edit = %v", first)
+	}
+	if _, ok := second["sourceBranch"]; ok {
+		t.Errorf("second edit recreates the branch: %v", second)
+	}
+	if _, ok := second["sourceCommitId"]; ok || second["path"] != "docs/usage.md" {
+		t.Errorf("second edit = %v", second)
+	}
+}
+
+func TestEditFiles_ChainsUpdates(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"fix": baseHead}}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	args := editFilesTestArgs()
+	args.Changes[1].Action = "update"
+	if _, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args); err != nil {
+		t.Fatalf("editFiles: %v", err)
+	}
+	if repo.edits[1]["sourceCommitId"] != "c1" {
+		t.Errorf("second edit based on %q, want c1", repo.edits[1]["sourceCommitId"])
+	}
+}
+
+func TestEditFiles_HeadMovedBeforeStart(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"fix": "fedcba9876543210fedcba9876543210fedcba98"}}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	args := editFilesTestArgs()
+	args.ExpectedHead = baseHead
+	_, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args)
+	if err == nil || !strings.Contains(err.Error(), "nothing was committed") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(repo.edits) != 0 {
+		t.Errorf("made %d edits", len(repo.edits))
+	}
+}
+
+func TestEditFiles_ShortExpectedHead(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"fix": baseHead}}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	// A one-character prefix of the real head would match it, and nearly any other head.
+	args := editFilesTestArgs()
+	args.ExpectedHead = baseHead[:1]
+	_, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args)
+	if err == nil || !strings.Contains(err.Error(), "at least 7 characters") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(repo.edits) != 0 {
+		t.Errorf("made %d edits", len(repo.edits))
+	}
+}
+
+func TestEditFiles_HeadMovedDuringCommit(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"fix": baseHead}, pushAt: 2}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	_, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, editFilesTestArgs())
+	if err == nil || !strings.Contains(err.Error(), "moved") || !strings.Contains(err.Error(), "reset fix to "+baseHead) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestEditFiles_FailureMidway(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"main": baseHead}, failAt: 2}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	args := editFilesTestArgs()
+	args.SourceBranch = "main"
+	_, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args)
+	if err == nil || !strings.Contains(err.Error(), "README.md; head c1") || !strings.Contains(err.Error(), "delete branch fix") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestEditFiles_Validation(t *testing.T) {
+	repo := &fakeRepo{heads: map[string]string{"main": baseHead}}
+	srv, ts := bbServer(repo.mux(t))
+	defer ts.Close()
+
+	tests := map[string]func(*editFilesArgs){
+		"no changes":        func(a *editFilesArgs) { a.Changes = nil },
+		"no message":        func(a *editFilesArgs) { a.Message = "" },
+		"delete":            func(a *editFilesArgs) { a.Changes[0].Action = "delete" },
+		"unknown action":    func(a *editFilesArgs) { a.Changes[0].Action = "rename" },
+		"no path":           func(a *editFilesArgs) { a.Changes[1].Path = "" },
+		"missing branch":    func(a *editFilesArgs) {},
+		"missing source":    func(a *editFilesArgs) { a.SourceBranch = "nope" },
+		"missing workspace": func(a *editFilesArgs) { srv.defaultProjectKey = "" },
+	}
+	for name, mutate := range tests {
+		args := editFilesTestArgs()
+		mutate(&args)
+		if _, _, err := srv.editFiles(context.Background(), &sdkmcp.CallToolRequest{}, args); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		srv.defaultProjectKey = "PROJ"
+	}
+	if len(repo.edits) != 0 {
+		t.Errorf("made %d edits", len(repo.edits))
+	}
+}
@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerInsightTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_create_insight_report",
+		Description: "Publish a Code Insights report (with optional annotations) on a commit; replaces an existing report with the same key",
+	}, s.createInsightReport)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_insight_report",
+		Description: "Get a Code Insights report and its annotations",
+	}, s.getInsightReport)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_delete_insight_report",
+		Description: "Delete a Code Insights report and its annotations",
+	}, s.deleteInsightReport)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_add_insight_annotations",
+		Description: "Add annotations (path, line, severity, type, message) to an existing Code Insights report",
+	}, s.addInsightAnnotations)
+}
+
+type insightReportRef struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	CommitID      string `json:"commitId" jsonschema:"required,Full commit hash"`
+	ReportKey     string `json:"reportKey" jsonschema:"required,Unique report key (e.g. com.example.lint)"`
+}
+
+type createInsightReportArgs struct {
+	insightReportRef
+	Title       string                        `json:"title" jsonschema:"required"`
+	Details     string                        `json:"details"`
+	Result      string                        `json:"result" jsonschema:"PASS or FAIL"`
+	Reporter    string                        `json:"reporter"`
+	Link        string                        `json:"link"`
+	LogoURL     string                        `json:"logoUrl"`
+	Data        []bitbucket.InsightData       `json:"data" jsonschema:"Up to 6 data fields shown on the report"`
+	Annotations []bitbucket.InsightAnnotation `json:"annotations" jsonschema:"Findings to attach (max 1000 per report)"`
+}
+
+type insightReportResult struct {
+	Report      *bitbucket.InsightReport      `json:"report"`
+	Annotations []bitbucket.InsightAnnotation `json:"annotations,omitempty"`
+	Total       int                           `json:"annotationCount"`
+}
+
+func (s *Server) createInsightReport(ctx context.Context, req *mcp.CallToolRequest, args createInsightReportArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	report, err := s.client.CreateInsightReport(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, bitbucket.InsightReport{
+		Title:    args.Title,
+		Details:  args.Details,
+		Result:   args.Result,
+		Reporter: args.Reporter,
+		Link:     args.Link,
+		LogoURL:  args.LogoURL,
+		Data:     args.Data,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(args.Annotations) > 0 {
+		if err := s.client.AddInsightAnnotations(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, args.Annotations, opts); err != nil {
+			return nil, nil, fmt.Errorf("report created but annotations failed: %w", err)
+		}
+	}
+	data, err := json.Marshal(insightReportResult{Report: report, Total: len(args.Annotations)})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+func (s *Server) getInsightReport(ctx context.Context, req *mcp.CallToolRequest, args insightReportRef) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	report, err := s.client.GetInsightReport(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	annotations, err := s.client.ListInsightAnnotations(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(insightReportResult{Report: report, Annotations: annotations.Annotations, Total: annotations.TotalCount})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+func (s *Server) deleteInsightReport(ctx context.Context, req *mcp.CallToolRequest, args insightReportRef) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.DeleteInsightReport(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "deleted"}}}, nil, nil
+}
+
+type addInsightAnnotationsArgs struct {
+	insightReportRef
+	Annotations []bitbucket.InsightAnnotation `json:"annotations" jsonschema:"required"`
+}
+
+func (s *Server) addInsightAnnotations(ctx context.Context, req *mcp.CallToolRequest, args addInsightAnnotationsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if len(args.Annotations) == 0 {
+		return nil, nil, fmt.Errorf("annotations required")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.AddInsightAnnotations(ctx, projectKey, args.Repository, args.CommitID, args.ReportKey, args.Annotations, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("added %d annotations", len(args.Annotations))}}}, nil, nil
+}
@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const insightsReportPath = "/rest/insights/1.0/projects/PROJ/repos/repo/commits/abc/reports/lint"
+
+var lintReport = insightReportRef{Repository: "repo", CommitID: "abc", ReportKey: "lint"}
+
+func TestCreateInsightReport(t *testing.T) {
+	annotated := false
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint","result":"FAIL"}`))
+	})
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		annotated = true
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.createInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, createInsightReportArgs{
+		insightReportRef: lintReport,
+		Title:            "Lint",
+		Result:           "FAIL",
+		Annotations:      []bitbucket.InsightAnnotation{{Path: "a.go", Line: 1, Message: "unused", Severity: "LOW"}},
+	})
+	if err != nil {
+		t.Fatalf("createInsightReport: %v", err)
+	}
+	if !annotated {
+		t.Error("annotations not posted")
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"annotationCount":1`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestCreateInsightReport_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint"}`))
+	})
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, createInsightReportArgs{
+		insightReportRef: lintReport,
+		Title:            "Lint",
+		Annotations:      []bitbucket.InsightAnnotation{{Message: "m", Severity: "BOGUS"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "annotations failed") {
+		t.Errorf("err = %v, want annotations failure", err)
+	}
+
+	badKey := lintReport
+	badKey.ReportKey = "missing"
+	if _, _, err := srv.createInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, createInsightReportArgs{insightReportRef: badKey, Title: "x"}); err == nil {
+		t.Error("expected report error")
+	}
+
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.createInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, createInsightReportArgs{insightReportRef: lintReport, Title: "x"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestGetInsightReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint","result":"PASS"}`))
+	})
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"annotations":[{"message":"m","severity":"LOW"}],"totalCount":1}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport)
+	if err != nil {
+		t.Fatalf("getInsightReport: %v", err)
+	}
+	text := result.Content[0].(*sdkmcp.TextContent).Text
+	if !strings.Contains(text, `"result":"PASS"`) || !strings.Contains(text, `"annotationCount":1`) {
+		t.Errorf("result = %s", text)
+	}
+}
+
+func TestGetInsightReport_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"lint","title":"Lint"}`))
+	})
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport); err == nil {
+		t.Error("expected annotations error")
+	}
+	badKey := lintReport
+	badKey.ReportKey = "missing"
+	if _, _, err := srv.getInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, badKey); err == nil {
+		t.Error("expected report error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestDeleteInsightReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport); err != nil {
+		t.Fatalf("deleteInsightReport: %v", err)
+	}
+}
+
+func TestDeleteInsightReport_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.deleteInsightReport(context.Background(), &sdkmcp.CallToolRequest{}, lintReport); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestAddInsightAnnotations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.addInsightAnnotations(context.Background(), &sdkmcp.CallToolRequest{}, addInsightAnnotationsArgs{
+		insightReportRef: lintReport,
+		Annotations:      []bitbucket.InsightAnnotation{{Message: "a", Severity: "HIGH"}, {Message: "b", Severity: "LOW"}},
+	})
+	if err != nil {
+		t.Fatalf("addInsightAnnotations: %v", err)
+	}
+	if result.Content[0].(*sdkmcp.TextContent).Text != "added 2 annotations" {
+		t.Errorf("result = %q", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestAddInsightAnnotations_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(insightsReportPath+"/annotations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	one := []bitbucket.InsightAnnotation{{Message: "a", Severity: "HIGH"}}
+	if _, _, err := srv.addInsightAnnotations(context.Background(), &sdkmcp.CallToolRequest{}, addInsightAnnotationsArgs{insightReportRef: lintReport, Annotations: one}); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv.addInsightAnnotations(context.Background(), &sdkmcp.CallToolRequest{}, addInsightAnnotationsArgs{insightReportRef: lintReport}); err == nil {
+		t.Error("expected error for empty annotations")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.addInsightAnnotations(context.Background(), &sdkmcp.CallToolRequest{}, addInsightAnnotationsArgs{insightReportRef: lintReport, Annotations: one}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
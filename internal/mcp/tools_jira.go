@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+// Sources of the issue keys returned by the Jira tools.
+const (
+	issueSourceJira      = "jira"      // linked by the Jira integration
+	issueSourceExtracted = "extracted" // found in branch names, titles or commit messages
+)
+
+// maxPullRequestCommitsScanned bounds the commits read when extracting keys from a PR.
+const maxPullRequestCommitsScanned = 100
+
+func (s *Server) registerJiraTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name: "bitbucket_get_pull_request_issues",
+		Description: "List the Jira issues linked to a pull request. Without the Jira integration, " +
+			"extracts issue keys (e.g. ABC-123) from the branch name, title, description and commit messages",
+	}, s.getPullRequestIssues)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name: "bitbucket_get_commit_issues",
+		Description: "List the Jira issue keys of a commit, from the Jira integration when installed, " +
+			"otherwise extracted from the commit message",
+	}, s.getCommitIssues)
+}
+
+type issuesResult struct {
+	Source string                `json:"source"`
+	Issues []bitbucket.JiraIssue `json:"issues"`
+}
+
+func keyIssues(keys []string) []bitbucket.JiraIssue {
+	issues := make([]bitbucket.JiraIssue, len(keys))
+	for i, k := range keys {
+		issues[i] = bitbucket.JiraIssue{Key: k}
+	}
+	return issues
+}
+
+type getPRIssuesArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	PrID          int    `json:"prId" jsonschema:"required"`
+}
+
+func (s *Server) getPullRequestIssues(ctx context.Context, req *mcp.CallToolRequest, args getPRIssuesArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	out := issuesResult{Source: issueSourceJira}
+	issues, err := s.client.GetPullRequestIssues(ctx, projectKey, args.Repository, args.PrID, opts)
+	switch {
+	case errors.Is(err, bitbucket.ErrJiraUnavailable):
+		keys, err := s.pullRequestIssueKeys(ctx, projectKey, args.Repository, args.PrID, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = issuesResult{Source: issueSourceExtracted, Issues: keyIssues(keys)}
+	case err != nil:
+		return nil, nil, err
+	default:
+		out.Issues = issues
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// pullRequestIssueKeys extracts issue keys from a pull request's source branch, title,
+// description and the messages of its first commits.
+func (s *Server) pullRequestIssueKeys(ctx context.Context, projectKey, repo string, prID int, opts bitbucket.RequestOpts) ([]string, error) {
+	pr, err := s.client.GetPullRequest(ctx, projectKey, repo, prID, opts)
+	if err != nil {
+		return nil, err
+	}
+	var texts []string
+	if pr.FromRef != nil {
+		texts = append(texts, pr.FromRef.DisplayID)
+	}
+	texts = append(texts, pr.Title, pr.Description)
+	commits, err := s.client.ListPullRequestCommits(ctx, projectKey, repo, prID, 0, maxPullRequestCommitsScanned, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commits.Values {
+		texts = append(texts, c.Message)
+	}
+	return bitbucket.ExtractIssueKeys(texts...), nil
+}
+
+type getCommitIssuesArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	CommitID      string `json:"commitId" jsonschema:"required,Commit hash"`
+}
+
+func (s *Server) getCommitIssues(ctx context.Context, req *mcp.CallToolRequest, args getCommitIssuesArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	commit, err := s.client.GetCommit(ctx, projectKey, args.Repository, args.CommitID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := issuesResult{Source: issueSourceExtracted, Issues: keyIssues(bitbucket.ExtractIssueKeys(commit.Message))}
+	if commit.Properties != nil && len(commit.Properties.JiraKeys) > 0 {
+		out = issuesResult{Source: issueSourceJira, Issues: keyIssues(commit.Properties.JiraKeys)}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
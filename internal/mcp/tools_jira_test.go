@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const jiraPRPath = "/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/5"
+
+func jiraTestMux(withJira bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	if withJira {
+		mux.HandleFunc("/rest/jira/1.0/projects/PROJ/repos/repo/pull-requests/5/issues", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"key":"ABC-1","url":"https://jira.example.com/browse/ABC-1"}]`))
+		})
+	}
+	mux.HandleFunc(jiraPRPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":5,"title":"Login page","description":"Also closes OPS-9",
+			"fromRef":{"id":"refs/heads/feature/ABC-7-login","displayId":"feature/ABC-7-login"}}`))
+	})
+	mux.HandleFunc(jiraPRPath+"/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"a","message":"ABC-7 form"},{"id":"b","message":"DEV-3 tests"}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/linked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"linked","message":"Fix","properties":{"jira-key":["ABC-2"]}}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/commits/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"plain","message":"OPS-4: Fix retry"}`))
+	})
+	return mux
+}
+
+func TestGetPullRequestIssues(t *testing.T) {
+	srv, ts := bbServer(jiraTestMux(true))
+	defer ts.Close()
+
+	result, _, err := srv.getPullRequestIssues(context.Background(), &sdkmcp.CallToolRequest{}, getPRIssuesArgs{Repository: "repo", PrID: 5})
+	if err != nil {
+		t.Fatalf("getPullRequestIssues: %v", err)
+	}
+	want := `{"source":"jira","issues":[{"key":"ABC-1","url":"https://jira.example.com/browse/ABC-1"}]}`
+	if got := result.Content[0].(*sdkmcp.TextContent).Text; got != want {
+		t.Errorf("result = %s, want %s", got, want)
+	}
+}
+
+func TestGetPullRequestIssues_Extracted(t *testing.T) {
+	srv, ts := bbServer(jiraTestMux(false))
+	defer ts.Close()
+
+	result, _, err := srv.getPullRequestIssues(context.Background(), &sdkmcp.CallToolRequest{}, getPRIssuesArgs{Repository: "repo", PrID: 5})
+	if err != nil {
+		t.Fatalf("getPullRequestIssues: %v", err)
+	}
+	want := `{"source":"extracted","issues":[{"key":"ABC-7"},{"key":"OPS-9"},{"key":"DEV-3"}]}`
+	if got := result.Content[0].(*sdkmcp.TextContent).Text; got != want {
+		t.Errorf("result = %s, want %s", got, want)
+	}
+}
+
+func TestGetPullRequestIssues_Errors(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.getPullRequestIssues(context.Background(), &sdkmcp.CallToolRequest{}, getPRIssuesArgs{Repository: "repo", PrID: 5}); err == nil {
+		t.Error("expected error for missing pull request")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getPullRequestIssues(context.Background(), &sdkmcp.CallToolRequest{}, getPRIssuesArgs{Repository: "repo", PrID: 5}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestGetCommitIssues(t *testing.T) {
+	srv, ts := bbServer(jiraTestMux(false))
+	defer ts.Close()
+
+	for commit, want := range map[string]string{
+		"linked": `{"source":"jira","issues":[{"key":"ABC-2"}]}`,
+		"plain":  `{"source":"extracted","issues":[{"key":"OPS-4"}]}`,
+	} {
+		result, _, err := srv.getCommitIssues(context.Background(), &sdkmcp.CallToolRequest{}, getCommitIssuesArgs{Repository: "repo", CommitID: commit})
+		if err != nil {
+			t.Fatalf("getCommitIssues(%s): %v", commit, err)
+		}
+		if got := result.Content[0].(*sdkmcp.TextContent).Text; got != want {
+			t.Errorf("%s: result = %s, want %s", commit, got, want)
+		}
+	}
+}
+
+func TestGetCommitIssues_Errors(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.getCommitIssues(context.Background(), &sdkmcp.CallToolRequest{}, getCommitIssuesArgs{Repository: "repo", CommitID: "x"}); err == nil {
+		t.Error("expected error for missing commit")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getCommitIssues(context.Background(), &sdkmcp.CallToolRequest{}, getCommitIssuesArgs{Repository: "repo", CommitID: "x"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+// maxPermissionPages bounds how many pages bitbucket_who_has_access reads per list.
+const maxPermissionPages = 20
+
+func (s *Server) registerPermissionTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_permissions",
+		Description: "List users and groups with explicit permissions on a project, or on a repository when repository is set",
+	}, s.listPermissions)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_grant_permission",
+		Description: "Grant a project or repository permission to users and/or groups (replaces their existing permission at that scope)",
+	}, s.grantPermission)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_revoke_permission",
+		Description: "Revoke a user's or group's explicit permission on a project or repository",
+	}, s.revokePermission)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_who_has_access",
+		Description: "List users and groups with at least the given access to a repository, combining repository and project grants",
+	}, s.whoHasAccess)
+}
+
+type listPermissionsArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for project-level permissions"`
+	Filter        string `json:"filter" jsonschema:"Only principals whose name contains this text"`
+}
+
+type permissionsResult struct {
+	Users  *bitbucket.UserPermissionsResponse  `json:"users"`
+	Groups *bitbucket.GroupPermissionsResponse `json:"groups"`
+}
+
+func (s *Server) listPermissions(ctx context.Context, req *mcp.CallToolRequest, args listPermissionsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	users, err := s.client.ListUserPermissions(ctx, projectKey, args.Repository, args.Filter, 0, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	groups, err := s.client.ListGroupPermissions(ctx, projectKey, args.Repository, args.Filter, 0, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(permissionsResult{Users: users, Groups: groups})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type grantPermissionArgs struct {
+	WorkspaceSlug string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string   `json:"repository" jsonschema:"Repository slug; omit to grant on the project"`
+	Users         []string `json:"users" jsonschema:"Usernames to grant"`
+	Groups        []string `json:"groups" jsonschema:"Group names to grant"`
+	Permission    string   `json:"permission" jsonschema:"required,READ, WRITE or ADMIN (or the full REPO_*/PROJECT_* name)"`
+}
+
+func (s *Server) grantPermission(ctx context.Context, req *mcp.CallToolRequest, args grantPermissionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if len(args.Users) == 0 && len(args.Groups) == 0 {
+		return nil, nil, fmt.Errorf("users or groups required")
+	}
+	permission := bitbucket.NormalizePermission(args.Repository, args.Permission)
+	opts := s.getOpts(ctx, req)
+	if len(args.Users) > 0 {
+		if err := s.client.GrantPermission(ctx, projectKey, args.Repository, "users", args.Users, permission, opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(args.Groups) > 0 {
+		if err := s.client.GrantPermission(ctx, projectKey, args.Repository, "groups", args.Groups, permission, opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "granted " + permission}}}, nil, nil
+}
+
+type revokePermissionArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit to revoke on the project"`
+	User          string `json:"user" jsonschema:"Username to revoke"`
+	Group         string `json:"group" jsonschema:"Group name to revoke"`
+}
+
+func (s *Server) revokePermission(ctx context.Context, req *mcp.CallToolRequest, args revokePermissionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if (args.User == "") == (args.Group == "") {
+		return nil, nil, fmt.Errorf("exactly one of user or group required")
+	}
+	kind, name := "users", args.User
+	if args.Group != "" {
+		kind, name = "groups", args.Group
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.RevokePermission(ctx, projectKey, args.Repository, kind, name, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "revoked"}}}, nil, nil
+}
+
+type whoHasAccessArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+	MinPermission string `json:"minPermission" jsonschema:"READ, WRITE (default) or ADMIN"`
+}
+
+// accessGrant is one explicit grant that gives a principal access to a repository.
+type accessGrant struct {
+	Type        string `json:"type"` // "user" or "group"
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Permission  string `json:"permission"`
+	Scope       string `json:"scope"` // "repository" or "project"
+}
+
+type whoHasAccessResult struct {
+	MinPermission string        `json:"minPermission"`
+	Grants        []accessGrant `json:"grants"`
+	// Truncated reports that a permission list had more than maxPermissionPages pages.
+	Truncated bool   `json:"truncated"`
+	Note      string `json:"note"`
+}
+
+func (s *Server) whoHasAccess(ctx context.Context, req *mcp.CallToolRequest, args whoHasAccessArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	minPermission := args.MinPermission
+	if minPermission == "" {
+		minPermission = "WRITE"
+	}
+	minRank := bitbucket.PermissionRank(minPermission)
+	if minRank == 0 {
+		return nil, nil, fmt.Errorf("minPermission must be READ, WRITE or ADMIN")
+	}
+	opts := s.getOpts(ctx, req)
+
+	grants := []accessGrant{}
+	truncated := false
+	// Project grants are inherited by every repository in the project.
+	for _, scope := range []struct{ name, repoSlug string }{{"repository", args.Repository}, {"project", ""}} {
+		for start, page := 0, 0; ; page++ {
+			resp, err := s.client.ListUserPermissions(ctx, projectKey, scope.repoSlug, "", start, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, p := range resp.Values {
+				if p.User != nil && bitbucket.PermissionRank(p.Permission) >= minRank {
+					grants = append(grants, accessGrant{Type: "user", Name: p.User.Name, DisplayName: p.User.DisplayName, Permission: p.Permission, Scope: scope.name})
+				}
+			}
+			if resp.IsLastPage {
+				break
+			}
+			if page+1 == maxPermissionPages {
+				truncated = true
+				break
+			}
+			start = resp.NextPageStart
+		}
+		for start, page := 0, 0; ; page++ {
+			resp, err := s.client.ListGroupPermissions(ctx, projectKey, scope.repoSlug, "", start, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, p := range resp.Values {
+				if p.Group != nil && bitbucket.PermissionRank(p.Permission) >= minRank {
+					grants = append(grants, accessGrant{Type: "group", Name: p.Group.Name, Permission: p.Permission, Scope: scope.name})
+				}
+			}
+			if resp.IsLastPage {
+				break
+			}
+			if page+1 == maxPermissionPages {
+				truncated = true
+				break
+			}
+			start = resp.NextPageStart
+		}
+	}
+	sort.SliceStable(grants, func(i, j int) bool {
+		if grants[i].Type != grants[j].Type {
+			return grants[i].Type > grants[j].Type // users before groups
+		}
+		return strings.ToLower(grants[i].Name) < strings.ToLower(grants[j].Name)
+	})
+	note := "Explicit grants only; global admins, public access and default project permissions are not listed."
+	if truncated {
+		note += fmt.Sprintf(" Only the first %d pages of each permission list were read, so some grants are missing.", maxPermissionPages)
+	}
+	data, err := json.Marshal(whoHasAccessResult{
+		MinPermission: strings.ToUpper(minPermission),
+		Grants:        grants,
+		Truncated:     truncated,
+		Note:          note,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -0,0 +1,319 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestListPermissions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"user":{"name":"alice"},"permission":"REPO_ADMIN"}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"group":{"name":"devs"},"permission":"REPO_WRITE"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listPermissions(context.Background(), &sdkmcp.CallToolRequest{}, listPermissionsArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("listPermissions: %v", err)
+	}
+	text := result.Content[0].(*sdkmcp.TextContent).Text
+	if !strings.Contains(text, "alice") || !strings.Contains(text, "devs") {
+		t.Errorf("result = %s", text)
+	}
+}
+
+func TestListPermissions_NoWorkspace(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+	srv.defaultProjectKey = ""
+
+	_, _, err := srv.listPermissions(context.Background(), &sdkmcp.CallToolRequest{}, listPermissionsArgs{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListPermissions_UsersError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.listPermissions(context.Background(), &sdkmcp.CallToolRequest{}, listPermissionsArgs{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListPermissions_GroupsError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.listPermissions(context.Background(), &sdkmcp.CallToolRequest{}, listPermissionsArgs{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGrantPermission(t *testing.T) {
+	var gotUsers, gotGroups string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		gotUsers = r.URL.Query().Get("permission")
+		w.WriteHeader(204)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		gotGroups = r.URL.Query().Get("permission")
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{
+		Repository: "repo", Users: []string{"alice"}, Groups: []string{"devs"}, Permission: "write",
+	})
+	if err != nil {
+		t.Fatalf("grantPermission: %v", err)
+	}
+	if gotUsers != "REPO_WRITE" || gotGroups != "REPO_WRITE" {
+		t.Errorf("permission users=%q groups=%q", gotUsers, gotGroups)
+	}
+	if result.Content[0].(*sdkmcp.TextContent).Text != "granted REPO_WRITE" {
+		t.Errorf("result = %q", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestGrantPermission_Validation(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{Permission: "READ"}); err == nil {
+		t.Error("expected error without users or groups")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{Users: []string{"a"}, Permission: "READ"}); err == nil {
+		t.Error("expected error without workspace")
+	}
+}
+
+func TestGrantPermission_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{
+		Users: []string{"alice"}, Groups: []string{"missing"}, Permission: "READ",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	_, _, err = srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{
+		Groups: []string{"missing"}, Permission: "READ",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGrantPermission_UsersAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.grantPermission(context.Background(), &sdkmcp.CallToolRequest{}, grantPermissionArgs{
+		Users: []string{"ghost"}, Permission: "READ",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRevokePermission(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "devs" {
+			t.Errorf("name = %q", r.URL.Query().Get("name"))
+		}
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.revokePermission(context.Background(), &sdkmcp.CallToolRequest{}, revokePermissionArgs{Group: "devs"})
+	if err != nil {
+		t.Fatalf("revokePermission: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Error("expected content")
+	}
+}
+
+func TestRevokePermission_Validation(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.revokePermission(context.Background(), &sdkmcp.CallToolRequest{}, revokePermissionArgs{}); err == nil {
+		t.Error("expected error without user or group")
+	}
+	if _, _, err := srv.revokePermission(context.Background(), &sdkmcp.CallToolRequest{}, revokePermissionArgs{User: "a", Group: "b"}); err == nil {
+		t.Error("expected error with both user and group")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.revokePermission(context.Background(), &sdkmcp.CallToolRequest{}, revokePermissionArgs{User: "a"}); err == nil {
+		t.Error("expected error without workspace")
+	}
+}
+
+func TestRevokePermission_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.revokePermission(context.Background(), &sdkmcp.CallToolRequest{}, revokePermissionArgs{Repository: "repo", User: "me"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWhoHasAccess(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("start") == "" {
+			_, _ = w.Write([]byte(`{"values":[{"user":{"name":"carol"},"permission":"REPO_READ"}],"isLastPage":false,"nextPageStart":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"user":{"name":"bob","displayName":"Bob"},"permission":"REPO_WRITE"}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"user":{"name":"alice"},"permission":"PROJECT_ADMIN"}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"group":{"name":"devs"},"permission":"PROJECT_WRITE"},{"group":{"name":"viewers"},"permission":"PROJECT_READ"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.whoHasAccess(context.Background(), &sdkmcp.CallToolRequest{}, whoHasAccessArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("whoHasAccess: %v", err)
+	}
+	var out whoHasAccessResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	var names []string
+	for _, g := range out.Grants {
+		names = append(names, g.Type+":"+g.Name+"@"+g.Scope)
+	}
+	want := "user:alice@project,user:bob@repository,group:devs@project"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("grants = %s, want %s", got, want)
+	}
+	if out.Truncated {
+		t.Error("Truncated = true, want false")
+	}
+}
+
+func TestWhoHasAccess_Truncated(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/users", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"user":{"name":"bob"},"permission":"REPO_WRITE"}],"isLastPage":false,"nextPageStart":1}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.whoHasAccess(context.Background(), &sdkmcp.CallToolRequest{}, whoHasAccessArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("whoHasAccess: %v", err)
+	}
+	var out whoHasAccessResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !out.Truncated || !strings.Contains(out.Note, "first 20 pages") || calls.Load() != maxPermissionPages {
+		t.Errorf("truncated = %v, note = %q, calls = %d", out.Truncated, out.Note, calls.Load())
+	}
+}
+
+func TestWhoHasAccess_Validation(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	if _, _, err := srv.whoHasAccess(context.Background(), &sdkmcp.CallToolRequest{}, whoHasAccessArgs{Repository: "repo", MinPermission: "OWNER"}); err == nil {
+		t.Error("expected error for invalid minPermission")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.whoHasAccess(context.Background(), &sdkmcp.CallToolRequest{}, whoHasAccessArgs{Repository: "repo"}); err == nil {
+		t.Error("expected error without workspace")
+	}
+}
+
+func TestWhoHasAccess_APIErrors(t *testing.T) {
+	for _, failing := range []string{"users", "groups"} {
+		t.Run(failing, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/"+failing, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(403)
+			})
+			mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/permissions/", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+			})
+			srv, ts := bbServer(mux)
+			defer ts.Close()
+
+			_, _, err := srv.whoHasAccess(context.Background(), &sdkmcp.CallToolRequest{}, whoHasAccessArgs{Repository: "repo", MinPermission: "read"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -30,6 +30,10 @@ func (s *Server) registerPRTools() {
 		Name:        "bitbucket_merge_pull_request",
 		Description: "Merge a pull request",
 	}, s.mergePullRequest)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_pull_request_merge_status",
+		Description: "Check whether a pull request can be merged and list the merge checks blocking it",
+	}, s.getPullRequestMergeStatus)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "bitbucket_decline_pull_request",
 		Description: "Decline a pull request",
@@ -152,6 +156,32 @@ func (s *Server) mergePullRequest(ctx context.Context, req *mcp.CallToolRequest,
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "merged"}}}, nil, nil
 }
 
+type mergeStatusArgs struct {
+	Repository    string `json:"repository" jsonschema:"required"`
+	PrID          int    `json:"prId" jsonschema:"required"`
+	WorkspaceSlug string `json:"workspaceSlug"`
+}
+
+func (s *Server) getPullRequestMergeStatus(ctx context.Context, req *mcp.CallToolRequest, args mergeStatusArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required")
+	}
+	status, err := s.client.GetMergeStatus(ctx, projectKey, args.Repository, args.PrID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status.Vetoes == nil {
+		status.Vetoes = []bitbucket.MergeVeto{}
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
 type declinePRArgs struct {
 	Repository    string `json:"repository" jsonschema:"required"`
 	PrID          int    `json:"prId" jsonschema:"required"`
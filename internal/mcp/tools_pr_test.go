@@ -202,6 +202,41 @@ func TestMergePullRequest_NoWorkspace(t *testing.T) {
 	}
 }
 
+func TestGetPullRequestMergeStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"canMerge":true,"conflicted":false,"outcome":"CLEAN"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getPullRequestMergeStatus(context.Background(), &sdkmcp.CallToolRequest{}, mergeStatusArgs{Repository: "repo", PrID: 1})
+	if err != nil {
+		t.Fatalf("getPullRequestMergeStatus: %v", err)
+	}
+	if got := result.Content[0].(*sdkmcp.TextContent).Text; got != `{"canMerge":true,"conflicted":false,"outcome":"CLEAN","vetoes":[]}` {
+		t.Errorf("result = %s", got)
+	}
+}
+
+func TestGetPullRequestMergeStatus_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getPullRequestMergeStatus(context.Background(), &sdkmcp.CallToolRequest{}, mergeStatusArgs{Repository: "repo", PrID: 1}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getPullRequestMergeStatus(context.Background(), &sdkmcp.CallToolRequest{}, mergeStatusArgs{Repository: "repo", PrID: 1}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
 func TestDeclinePullRequest(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/decline", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerProjectTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_project",
+		Description: "Get project details (description, visibility, type, avatar link)",
+	}, s.getProject)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_create_project",
+		Description: "Create a new project (requires project create permission)",
+	}, s.createProject)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_update_project",
+		Description: "Update a project's name, key, description or visibility (requires project admin)",
+	}, s.updateProject)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_delete_project",
+		Description: "Delete an empty project (requires project admin)",
+	}, s.deleteProject)
+}
+
+type getProjectArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+}
+
+func (s *Server) getProject(ctx context.Context, req *mcp.CallToolRequest, args getProjectArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	project, err := s.client.GetProject(ctx, projectKey, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type createProjectArgs struct {
+	Key         string `json:"key" jsonschema:"required,Project key (A-Z0-9_)"`
+	Name        string `json:"name" jsonschema:"required"`
+	Description string `json:"description"`
+	Avatar      string `json:"avatar" jsonschema:"Avatar image as a data URI (data:image/png;base64,...)"`
+}
+
+func (s *Server) createProject(ctx context.Context, req *mcp.CallToolRequest, args createProjectArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	project, err := s.client.CreateProject(ctx, bitbucket.CreateProjectRequest{
+		Key:         args.Key,
+		Name:        args.Name,
+		Description: args.Description,
+		Avatar:      args.Avatar,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type updateProjectArgs struct {
+	WorkspaceSlug string  `json:"workspaceSlug" jsonschema:"required,Key of the project to update"`
+	Key           string  `json:"key" jsonschema:"New project key (renames the project)"`
+	Name          string  `json:"name"`
+	Description   *string `json:"description" jsonschema:"New description (empty string clears it)"`
+	Public        *bool   `json:"public" jsonschema:"Allow anonymous read access"`
+	Avatar        string  `json:"avatar" jsonschema:"Avatar image as a data URI"`
+}
+
+func (s *Server) updateProject(ctx context.Context, req *mcp.CallToolRequest, args updateProjectArgs) (*mcp.CallToolResult, any, error) {
+	if args.WorkspaceSlug == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required")
+	}
+	opts := s.getOpts(ctx, req)
+	project, err := s.client.UpdateProject(ctx, args.WorkspaceSlug, bitbucket.UpdateProjectRequest{
+		Key:         args.Key,
+		Name:        args.Name,
+		Description: args.Description,
+		Public:      args.Public,
+		Avatar:      args.Avatar,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type deleteProjectArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"required,Key of the project to delete"`
+}
+
+func (s *Server) deleteProject(ctx context.Context, req *mcp.CallToolRequest, args deleteProjectArgs) (*mcp.CallToolResult, any, error) {
+	// No default-project fallback: deleting must always name its target explicitly.
+	if args.WorkspaceSlug == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.DeleteProject(ctx, args.WorkspaceSlug, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "deleted"}}}, nil, nil
+}
@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGetProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ","name":"Project","description":"Core","type":"NORMAL"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getProject(context.Background(), &sdkmcp.CallToolRequest{}, getProjectArgs{})
+	if err != nil {
+		t.Fatalf("getProject: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"description":"Core"`) {
+		t.Error("expected description in result")
+	}
+}
+
+func TestGetProject_NoWorkspace(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+	srv.defaultProjectKey = ""
+
+	_, _, err := srv.getProject(context.Background(), &sdkmcp.CallToolRequest{}, getProjectArgs{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetProject_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.getProject(context.Background(), &sdkmcp.CallToolRequest{}, getProjectArgs{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(`{"key":"NEW","name":"New","id":3}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.createProject(context.Background(), &sdkmcp.CallToolRequest{}, createProjectArgs{Key: "NEW", Name: "New"})
+	if err != nil {
+		t.Fatalf("createProject: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Error("expected content")
+	}
+}
+
+func TestCreateProject_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createProject(context.Background(), &sdkmcp.CallToolRequest{}, createProjectArgs{Key: "NEW", Name: "New"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUpdateProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"PROJ","name":"Renamed"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.updateProject(context.Background(), &sdkmcp.CallToolRequest{}, updateProjectArgs{WorkspaceSlug: "PROJ", Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("updateProject: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "Renamed") {
+		t.Error("expected new name in result")
+	}
+}
+
+func TestUpdateProject_NoWorkspace(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	_, _, err := srv.updateProject(context.Background(), &sdkmcp.CallToolRequest{}, updateProjectArgs{Name: "x"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUpdateProject_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.updateProject(context.Background(), &sdkmcp.CallToolRequest{}, updateProjectArgs{WorkspaceSlug: "PROJ", Name: "x"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDeleteProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OLD", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.deleteProject(context.Background(), &sdkmcp.CallToolRequest{}, deleteProjectArgs{WorkspaceSlug: "OLD"})
+	if err != nil {
+		t.Fatalf("deleteProject: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Error("expected content")
+	}
+}
+
+func TestDeleteProject_NoDefaultFallback(t *testing.T) {
+	srv, ts := bbServer(http.NewServeMux())
+	defer ts.Close()
+
+	_, _, err := srv.deleteProject(context.Background(), &sdkmcp.CallToolRequest{}, deleteProjectArgs{})
+	if err == nil {
+		t.Fatal("expected error: delete must not fall back to the default project")
+	}
+}
+
+func TestDeleteProject_APIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/OLD", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.deleteProject(context.Background(), &sdkmcp.CallToolRequest{}, deleteProjectArgs{WorkspaceSlug: "OLD"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
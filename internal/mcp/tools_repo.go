@@ -3,9 +3,13 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
 )
 
 func (s *Server) registerRepoTools() {
@@ -13,18 +17,29 @@ func (s *Server) registerRepoTools() {
 		Name:        "bitbucket_list_repositories",
 		Description: "List repositories in a project/workspace",
 	}, s.listRepositories)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_search_repositories",
+		Description: "Find repositories by name across all projects the token can see (paginated)",
+	}, s.searchRepositories)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "bitbucket_get_repository_details",
-		Description: "Get repository information",
+		Description: "Get repository information including clone URLs, default branch, fork origin and size",
 	}, s.getRepositoryDetails)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
-		Name:        "bitbucket_search_content",
-		Description: "Search for code in repositories (Bitbucket Data Center 8+ with search enabled)",
+		Name: "bitbucket_search_content",
+		Description: "Search for code in repositories (Bitbucket Data Center 8+ with search enabled). " +
+			"Returns matching files with their repository and hit lines, matches wrapped in <em>. " +
+			"Page with start=nextStart until isLastPage.",
 	}, s.searchContent)
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "bitbucket_get_file_content",
 		Description: "Read file contents from a repository",
 	}, s.getFileContent)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name: "bitbucket_edit_file",
+		Description: "Create or update a single file on a branch as a new commit, without cloning. " +
+			"Pass sourceCommitId (the commit you read the file at) when updating an existing file.",
+	}, s.editFile)
 }
 
 type listReposArgs struct {
@@ -44,18 +59,71 @@ func (s *Server) listRepositories(ctx context.Context, req *mcp.CallToolRequest,
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
 }
 
+type searchReposArgs struct {
+	Name        string `json:"name" jsonschema:"Case-insensitive substring of the repository name"`
+	ProjectName string `json:"projectName" jsonschema:"Case-insensitive substring of the project name"`
+	Permission  string `json:"permission" jsonschema:"Only repos where the user has this permission: REPO_READ, REPO_WRITE or REPO_ADMIN"`
+	Visibility  string `json:"visibility" jsonschema:"public or private"`
+	Start       int    `json:"start" jsonschema:"Page start (nextPageStart from the previous page)"`
+	Limit       int    `json:"limit" jsonschema:"Page size (default: server default, usually 25)"`
+}
+
+func (s *Server) searchRepositories(ctx context.Context, req *mcp.CallToolRequest, args searchReposArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	resp, err := s.client.SearchRepositories(ctx, bitbucket.RepoSearchOptions{
+		Name:        args.Name,
+		ProjectName: args.ProjectName,
+		Permission:  args.Permission,
+		Visibility:  args.Visibility,
+		Start:       args.Start,
+		Limit:       args.Limit,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
 type getRepoDetailsArgs struct {
 	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"required"`
 	RepoSlug      string `json:"repoSlug" jsonschema:"required"`
 }
 
+// repositoryDetails is the bitbucket_get_repository_details result: the repository plus
+// best-effort extras that need separate API calls.
+type repositoryDetails struct {
+	*bitbucket.Repository
+	CloneURLs     map[string]string         `json:"cloneUrls,omitempty"`
+	DefaultBranch *bitbucket.Branch         `json:"defaultBranch,omitempty"`
+	Size          *bitbucket.RepositorySize `json:"size,omitempty"`
+}
+
 func (s *Server) getRepositoryDetails(ctx context.Context, req *mcp.CallToolRequest, args getRepoDetailsArgs) (*mcp.CallToolResult, any, error) {
 	opts := s.getOpts(ctx, req)
 	repo, err := s.client.GetRepository(ctx, args.WorkspaceSlug, args.RepoSlug, opts)
 	if err != nil {
 		return nil, nil, err
 	}
-	data, err := json.Marshal(repo)
+	out := repositoryDetails{Repository: repo}
+	if repo.Links != nil && len(repo.Links.Clone) > 0 {
+		out.CloneURLs = make(map[string]string, len(repo.Links.Clone))
+		for _, l := range repo.Links.Clone {
+			out.CloneURLs[l.Name] = l.Href
+		}
+	}
+	// Empty repositories have no default branch and the sizes endpoint may be
+	// unavailable; neither should fail the whole lookup.
+	if branch, err := s.client.GetDefaultBranch(ctx, args.WorkspaceSlug, args.RepoSlug, opts); err == nil {
+		out.DefaultBranch = branch
+	}
+	if size, err := s.client.GetRepositorySize(ctx, args.WorkspaceSlug, args.RepoSlug, opts); err == nil {
+		out.Size = size
+	}
+	data, err := json.Marshal(out)
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal response: %w", err)
 	}
@@ -63,14 +131,75 @@ func (s *Server) getRepositoryDetails(ctx context.Context, req *mcp.CallToolRequ
 }
 
 type searchContentArgs struct {
-	WorkspaceSlug string `json:"workspaceSlug"`
-	Query         string `json:"query" jsonschema:"required"`
-	Extension     string `json:"extension"`
+	WorkspaceSlug  string   `json:"workspaceSlug" jsonschema:"Only search this project (project: modifier)"`
+	Query          string   `json:"query" jsonschema:"Search text; may use AND, OR, NOT, quoted phrases and modifiers"`
+	AnyOf          []string `json:"anyOf" jsonschema:"Match at least one of these terms (OR)"`
+	Exclude        []string `json:"exclude" jsonschema:"Exclude files matching any of these terms (NOT)"`
+	Repository     string   `json:"repository" jsonschema:"Only search this repository slug (repo: modifier)"`
+	Language       string   `json:"language" jsonschema:"Only files in this language, e.g. java or go (lang: modifier)"`
+	Path           string   `json:"path" jsonschema:"Only files under this path, e.g. src/main (path: modifier)"`
+	Extension      string   `json:"extension" jsonschema:"Only files with this extension (ext: modifier)"`
+	Start          int      `json:"start" jsonschema:"Result offset (nextStart from the previous page)"`
+	Limit          int      `json:"limit" jsonschema:"Files per page (default: 25)"`
+	SecondaryLimit int      `json:"secondaryLimit" jsonschema:"Maximum repository and project matches returned alongside code (default: 10)"`
+	Fallback       *bool    `json:"fallback" jsonschema:"When the search index is unavailable (Bitbucket DC 7), read files instead: a file matches if it contains query and at least one anyOf term, literally and ignoring case, and no exclude term. Needs workspaceSlug; scans repository or the first 10 repositories of the project. Does not support language, modifiers or AND/OR/NOT in query"`
+	Ref            string   `json:"ref" jsonschema:"Branch or commit the fallback reads (default: default branch)"`
+	MaxFiles       int      `json:"maxFiles" jsonschema:"Maximum files the fallback reads (default: 200)"`
+}
+
+// maxFallbackRepos bounds how many repositories a grep fallback scans.
+const maxFallbackRepos = 10
+
+// searchModifiers are the query modifiers of Bitbucket code search.
+var searchModifiers = []string{"project:", "repo:", "lang:", "path:", "ext:", "fork:"}
+
+// fallbackUnsupported returns why the grep fallback cannot answer args as the search
+// index would, or "" when it can. The scan matches terms literally, so it refuses
+// query syntax rather than look for it as text.
+func fallbackUnsupported(args searchContentArgs) string {
+	if args.Language != "" {
+		return "language filter (use extension instead)"
+	}
+	for _, word := range strings.Fields(args.Query) {
+		switch {
+		case word == "AND" || word == "OR" || word == "NOT":
+			return word + " in query (use anyOf and exclude instead)"
+		case slices.ContainsFunc(searchModifiers, func(m string) bool { return strings.HasPrefix(strings.ToLower(word), m) }):
+			return "modifier " + word + " in query (use workspaceSlug, repository, path and extension instead)"
+		}
+	}
+	return ""
+}
+
+// searchFallbackResult is the bitbucket_search_content result when the search index is
+// unavailable and files were read instead.
+type searchFallbackResult struct {
+	SearchUnavailable bool   `json:"searchUnavailable"`
+	Notice            string `json:"notice"`
+	*bitbucket.GrepResponse
 }
 
 func (s *Server) searchContent(ctx context.Context, req *mcp.CallToolRequest, args searchContentArgs) (*mcp.CallToolResult, any, error) {
 	opts := s.getOpts(ctx, req)
-	resp, err := s.client.SearchContent(ctx, args.WorkspaceSlug, args.Query, args.Extension, opts)
+	resp, err := s.client.SearchContent(ctx, bitbucket.CodeSearchOptions{
+		Query:          args.Query,
+		AnyOf:          args.AnyOf,
+		Exclude:        args.Exclude,
+		Project:        args.WorkspaceSlug,
+		Repository:     args.Repository,
+		Language:       args.Language,
+		Path:           args.Path,
+		Extension:      args.Extension,
+		Start:          args.Start,
+		Limit:          args.Limit,
+		SecondaryLimit: args.SecondaryLimit,
+	}, opts)
+	if errors.Is(err, bitbucket.ErrSearchUnavailable) {
+		if args.Fallback == nil || !*args.Fallback {
+			return nil, nil, fmt.Errorf("%w; set fallback=true with workspaceSlug (and repository) to scan file contents instead", err)
+		}
+		return s.grepContent(ctx, args, opts)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,6 +210,60 @@ func (s *Server) searchContent(ctx context.Context, req *mcp.CallToolRequest, ar
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
 }
 
+// grepContent answers a code search by reading files when the search index is unavailable.
+func (s *Server) grepContent(ctx context.Context, args searchContentArgs, opts bitbucket.RequestOpts) (*mcp.CallToolResult, any, error) {
+	if args.WorkspaceSlug == "" {
+		return nil, nil, fmt.Errorf("%w; the fallback scan needs workspaceSlug", bitbucket.ErrSearchUnavailable)
+	}
+	if why := fallbackUnsupported(args); why != "" {
+		return nil, nil, fmt.Errorf("%w; the fallback scan does not support %s", bitbucket.ErrSearchUnavailable, why)
+	}
+	repos := []string{args.Repository}
+	truncatedRepos := false
+	if args.Repository == "" {
+		resp, err := s.client.ListRepositories(ctx, args.WorkspaceSlug, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		repos = repos[:0]
+		for _, r := range resp.Values {
+			if len(repos) == maxFallbackRepos {
+				truncatedRepos = true
+				break
+			}
+			repos = append(repos, r.Slug)
+		}
+		if !resp.IsLastPage {
+			truncatedRepos = true
+		}
+	}
+	resp, err := s.client.GrepContent(ctx, bitbucket.GrepOptions{
+		Project:      args.WorkspaceSlug,
+		Repositories: repos,
+		Query:        args.Query,
+		AnyOf:        args.AnyOf,
+		Exclude:      args.Exclude,
+		Ref:          args.Ref,
+		Path:         args.Path,
+		Extension:    args.Extension,
+		Limit:        args.Limit,
+		MaxFiles:     args.MaxFiles,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	notice := "Code search is unavailable; these results come from reading files and may be incomplete."
+	if truncatedRepos {
+		resp.Truncated = true
+		notice += fmt.Sprintf(" Only the first %d repositories were scanned; set repository to search another.", maxFallbackRepos)
+	}
+	data, err := json.Marshal(searchFallbackResult{SearchUnavailable: true, Notice: notice, GrepResponse: resp})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
 type getFileContentArgs struct {
 	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
 	RepoSlug      string `json:"repoSlug" jsonschema:"required"`
@@ -95,4 +278,41 @@ func (s *Server) getFileContent(ctx context.Context, req *mcp.CallToolRequest, a
 		return nil, nil, err
 	}
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: content}}}, nil, nil
-}
\ No newline at end of file
+}
+
+type editFileArgs struct {
+	WorkspaceSlug  string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	RepoSlug       string `json:"repoSlug" jsonschema:"required"`
+	FilePath       string `json:"filePath" jsonschema:"required"`
+	Content        string `json:"content" jsonschema:"required,New file content"`
+	Message        string `json:"message" jsonschema:"required,Commit message"`
+	Branch         string `json:"branch" jsonschema:"required,Branch to commit to"`
+	SourceCommitID string `json:"sourceCommitId" jsonschema:"Commit the edit is based on; required when the file already exists, omit to create a new file"`
+	SourceBranch   string `json:"sourceBranch" jsonschema:"Create branch from this branch first when it does not exist yet"`
+}
+
+func (s *Server) editFile(ctx context.Context, req *mcp.CallToolRequest, args editFileArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.FilePath == "" || args.Branch == "" || args.Message == "" {
+		return nil, nil, fmt.Errorf("filePath, branch and message are required")
+	}
+	opts := s.getOpts(ctx, req)
+	commit, err := s.client.EditFile(ctx, projectKey, args.RepoSlug, args.FilePath, bitbucket.EditFileRequest{
+		Content:        args.Content,
+		Message:        args.Message,
+		Branch:         args.Branch,
+		SourceCommitID: args.SourceCommitID,
+		SourceBranch:   args.SourceBranch,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(commit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -2,10 +2,15 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
 )
 
 func TestListRepositories(t *testing.T) {
@@ -45,6 +50,41 @@ func TestListRepositories_Error(t *testing.T) {
 	}
 }
 
+func TestSearchRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "api" {
+			t.Errorf("name = %q", r.URL.Query().Get("name"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"slug":"api","name":"API","project":{"key":"CORE"}}],"size":1,"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.searchRepositories(context.Background(), &sdkmcp.CallToolRequest{}, searchReposArgs{Name: "api", Limit: 10})
+	if err != nil {
+		t.Fatalf("searchRepositories: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"key":"CORE"`) {
+		t.Error("expected project key in result")
+	}
+}
+
+func TestSearchRepositories_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.searchRepositories(context.Background(), &sdkmcp.CallToolRequest{}, searchReposArgs{Name: "api"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func TestGetRepositoryDetails(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
@@ -65,6 +105,37 @@ func TestGetRepositoryDetails(t *testing.T) {
 	}
 }
 
+func TestGetRepositoryDetails_WithExtras(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","name":"Repo","id":1,"links":{"clone":[{"href":"ssh://git@bb/proj/repo.git","name":"ssh"}]}}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"refs/heads/main","displayId":"main","isDefault":true}`))
+	})
+	mux.HandleFunc("/projects/PROJ/repos/repo/sizes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"repository":1024,"attachments":0}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getRepositoryDetails(context.Background(), &sdkmcp.CallToolRequest{}, getRepoDetailsArgs{
+		WorkspaceSlug: "PROJ", RepoSlug: "repo",
+	})
+	if err != nil {
+		t.Fatalf("getRepositoryDetails: %v", err)
+	}
+	text := result.Content[0].(*sdkmcp.TextContent).Text
+	for _, want := range []string{`"slug":"repo"`, `"cloneUrls":{"ssh":"ssh://git@bb/proj/repo.git"}`, `"displayId":"main"`, `"repository":1024`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %s: %s", want, text)
+		}
+	}
+}
+
 func TestGetRepositoryDetails_Error(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/bad", func(w http.ResponseWriter, r *http.Request) {
@@ -85,8 +156,12 @@ func TestGetRepositoryDetails_Error(t *testing.T) {
 func TestSearchContent(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"values":[{"path":"main.go","content":"hello"}]}`))
+		_, _ = w.Write([]byte(`{"code":{"count":1,"isLastPage":true,"values":[{"repository":{"slug":"repo","project":{"key":"PROJ"}},
+			"file":"main.go","hitContexts":[[{"line":3,"text":"<em>hello</em>"}]],"hitCount":1}]}}`))
 	})
 	srv, ts := bbServer(mux)
 	defer ts.Close()
@@ -97,8 +172,45 @@ func TestSearchContent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("searchContent: %v", err)
 	}
-	if len(result.Content) == 0 {
-		t.Error("expected content")
+	text := result.Content[0].(*sdkmcp.TextContent).Text
+	for _, want := range []string{`"file":"main.go"`, `"key":"PROJ"`, `"line":3`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %s: %s", want, text)
+		}
+	}
+}
+
+func TestSearchContent_Modifiers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query    string `json:"query"`
+			Entities struct {
+				Code struct{ Start, Limit int } `json:"code"`
+			} `json:"entities"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if want := "NewClient NOT test project:PROJ repo:api lang:go path:internal"; body.Query != want {
+			t.Errorf("query = %q, want %q", body.Query, want)
+		}
+		if body.Entities.Code.Start != 25 || body.Entities.Code.Limit != 10 {
+			t.Errorf("page = %+v", body.Entities.Code)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":{"count":40,"start":25,"nextStart":35,"isLastPage":false,"values":[]}}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{
+		Query: "NewClient", Exclude: []string{"test"}, WorkspaceSlug: "PROJ", Repository: "api",
+		Language: "go", Path: "internal", Start: 25, Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("searchContent: %v", err)
+	}
+	if text := result.Content[0].(*sdkmcp.TextContent).Text; !strings.Contains(text, `"nextStart":35`) || !strings.Contains(text, `"isLastPage":false`) {
+		t.Errorf("result = %s", text)
 	}
 }
 
@@ -155,3 +267,189 @@ func TestGetFileContent_Error(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestEditFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/README.md", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		if r.FormValue("branch") != "fix" || r.FormValue("sourceBranch") != "master" || r.FormValue("sourceCommitId") != "abc" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"def","displayId":"def","message":"Fix docs"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.editFile(context.Background(), &sdkmcp.CallToolRequest{}, editFileArgs{
+		RepoSlug: "repo", FilePath: "README.md", Content: "# Docs\n", Message: "Fix docs",
+		Branch: "fix", SourceCommitID: "abc", SourceBranch: "master",
+	})
+	if err != nil {
+		t.Fatalf("editFile: %v", err)
+	}
+	if text := result.Content[0].(*sdkmcp.TextContent).Text; !strings.Contains(text, `"id":"def"`) {
+		t.Errorf("result = %s", text)
+	}
+}
+
+func TestEditFile_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/browse/README.md", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	args := editFileArgs{RepoSlug: "repo", FilePath: "README.md", Content: "x", Message: "m", Branch: "master"}
+	if _, _, err := srv.editFile(context.Background(), &sdkmcp.CallToolRequest{}, args); err == nil {
+		t.Error("expected API error")
+	}
+	noMessage := args
+	noMessage.Message = ""
+	if _, _, err := srv.editFile(context.Background(), &sdkmcp.CallToolRequest{}, noMessage); err == nil {
+		t.Error("expected error without message")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.editFile(context.Background(), &sdkmcp.CallToolRequest{}, args); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+// noSearchMux answers code search with 404, as Bitbucket Data Center 7 does, and serves
+// one file per repository in PROJ.
+func noSearchMux(repos ...string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/search/1.0/search", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	var values []string
+	for _, repo := range repos {
+		values = append(values, `{"slug":"`+repo+`"}`)
+		base := "/rest/api/1.0/projects/PROJ/repos/" + repo
+		mux.HandleFunc(base+"/files", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"values":["main.go"],"isLastPage":true}`))
+		})
+		mux.HandleFunc(base+"/raw/main.go", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("package main\n\nfunc NewClient() {}\n"))
+		})
+	}
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[` + strings.Join(values, ",") + `],"isLastPage":true}`))
+	})
+	return mux
+}
+
+func TestSearchContent_Unavailable(t *testing.T) {
+	srv, ts := bbServer(noSearchMux("repo"))
+	defer ts.Close()
+
+	_, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{Query: "NewClient"})
+	if !errors.Is(err, bitbucket.ErrSearchUnavailable) || !strings.Contains(err.Error(), "fallback=true") {
+		t.Fatalf("err = %v", err)
+	}
+	fallback := true
+	_, _, err = srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{Query: "NewClient", Fallback: &fallback})
+	if !errors.Is(err, bitbucket.ErrSearchUnavailable) || !strings.Contains(err.Error(), "workspaceSlug") {
+		t.Fatalf("fallback without workspace: err = %v", err)
+	}
+}
+
+func TestSearchContent_GrepFallback(t *testing.T) {
+	srv, ts := bbServer(noSearchMux("api", "web"))
+	defer ts.Close()
+
+	fallback := true
+	result, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{
+		Query: "newclient", WorkspaceSlug: "PROJ", Repository: "web", Fallback: &fallback,
+	})
+	if err != nil {
+		t.Fatalf("searchContent: %v", err)
+	}
+	var out struct {
+		SearchUnavailable bool                     `json:"searchUnavailable"`
+		Values            []bitbucket.SearchResult `json:"values"`
+		FilesScanned      int                      `json:"filesScanned"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.SearchUnavailable || out.FilesScanned != 1 || len(out.Values) != 1 || out.Values[0].Repository.Slug != "web" || out.Values[0].HitContexts[0][0].Line != 3 {
+		t.Errorf("out = %+v", out)
+	}
+}
+
+func TestSearchContent_GrepFallbackAnyOf(t *testing.T) {
+	srv, ts := bbServer(noSearchMux("web"))
+	defer ts.Close()
+
+	fallback := true
+	for anyOf, want := range map[string]int{"package": 1, "Dial": 0} {
+		result, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{
+			Query: "NewClient", AnyOf: []string{anyOf, "Retry"}, WorkspaceSlug: "PROJ", Repository: "web", Fallback: &fallback,
+		})
+		if err != nil {
+			t.Fatalf("searchContent: %v", err)
+		}
+		var out struct {
+			Count int `json:"count"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+			t.Fatal(err)
+		}
+		// main.go contains NewClient and package, but neither Dial nor Retry.
+		if out.Count != want {
+			t.Errorf("anyOf %s: count = %d, want %d", anyOf, out.Count, want)
+		}
+	}
+}
+
+func TestSearchContent_GrepFallbackUnsupported(t *testing.T) {
+	srv, ts := bbServer(noSearchMux("web"))
+	defer ts.Close()
+
+	fallback := true
+	for name, args := range map[string]searchContentArgs{
+		"language": {Query: "NewClient", Language: "go"},
+		"boolean":  {Query: "NewClient OR Dial"},
+		"modifier": {Query: "NewClient lang:go"},
+	} {
+		args.WorkspaceSlug, args.Repository, args.Fallback = "PROJ", "web", &fallback
+		_, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, args)
+		if !errors.Is(err, bitbucket.ErrSearchUnavailable) || !strings.Contains(err.Error(), "does not support") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+}
+
+func TestSearchContent_GrepFallbackProject(t *testing.T) {
+	var repos []string
+	for i := range maxFallbackRepos + 2 {
+		repos = append(repos, fmt.Sprintf("r%02d", i))
+	}
+	srv, ts := bbServer(noSearchMux(repos...))
+	defer ts.Close()
+
+	fallback := true
+	result, _, err := srv.searchContent(context.Background(), &sdkmcp.CallToolRequest{}, searchContentArgs{
+		Query: "NewClient", WorkspaceSlug: "PROJ", Fallback: &fallback, Limit: 100,
+	})
+	if err != nil {
+		t.Fatalf("searchContent: %v", err)
+	}
+	var out struct {
+		Count     int    `json:"count"`
+		Truncated bool   `json:"truncated"`
+		Notice    string `json:"notice"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Count != maxFallbackRepos || !out.Truncated || !strings.Contains(out.Notice, "first 10 repositories") {
+		t.Errorf("out = %+v", out)
+	}
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+var restrictionTypes = map[string]bool{
+	bitbucket.RestrictReadOnly:        true,
+	bitbucket.RestrictNoDeletes:       true,
+	bitbucket.RestrictFastForwardOnly: true,
+	bitbucket.RestrictPullRequestOnly: true,
+	bitbucket.RestrictNoCreates:       true,
+}
+
+var matcherTypes = map[string]bool{"BRANCH": true, "PATTERN": true, "MODEL_CATEGORY": true, "MODEL_BRANCH": true}
+
+func (s *Server) registerRestrictionTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_branch_restrictions",
+		Description: "List branch restrictions (read-only, no-deletes, fast-forward-only, pull-request-only) of a repository, or of a project when repository is omitted",
+	}, s.listBranchRestrictions)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_create_branch_restriction",
+		Description: "Restrict a branch, pattern or branching-model category, optionally exempting users and groups (requires admin)",
+	}, s.createBranchRestriction)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_delete_branch_restriction",
+		Description: "Delete a branch restriction by ID (requires admin)",
+	}, s.deleteBranchRestriction)
+}
+
+type listBranchRestrictionsArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for project restrictions"`
+	Type          string `json:"type" jsonschema:"Only this restriction type, e.g. pull-request-only"`
+	MatcherType   string `json:"matcherType" jsonschema:"Only this matcher type: BRANCH, PATTERN, MODEL_CATEGORY or MODEL_BRANCH"`
+	MatcherID     string `json:"matcherId" jsonschema:"Only this matcher, e.g. refs/heads/main or release/*"`
+	Start         int    `json:"start" jsonschema:"Page start (from nextPageStart)"`
+}
+
+func (s *Server) listBranchRestrictions(ctx context.Context, req *mcp.CallToolRequest, args listBranchRestrictionsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	filter := bitbucket.RestrictionFilter{Type: args.Type, MatcherType: args.MatcherType, MatcherID: args.MatcherID}
+	restrictions, err := s.client.ListBranchRestrictions(ctx, projectKey, args.Repository, filter, args.Start, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(restrictions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type createBranchRestrictionArgs struct {
+	WorkspaceSlug string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string   `json:"repository" jsonschema:"Repository slug; omit to restrict across the project"`
+	Type          string   `json:"type" jsonschema:"required,read-only, no-deletes, fast-forward-only, pull-request-only or no-creates"`
+	MatcherType   string   `json:"matcherType" jsonschema:"BRANCH (default), PATTERN, MODEL_CATEGORY or MODEL_BRANCH"`
+	Matcher       string   `json:"matcher" jsonschema:"required,Branch name, pattern (e.g. release/*), category (FEATURE, BUGFIX, HOTFIX, RELEASE) or model branch (production, development)"`
+	ExemptUsers   []string `json:"exemptUsers" jsonschema:"Usernames the restriction does not apply to"`
+	ExemptGroups  []string `json:"exemptGroups" jsonschema:"Groups the restriction does not apply to"`
+}
+
+func (s *Server) createBranchRestriction(ctx context.Context, req *mcp.CallToolRequest, args createBranchRestrictionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if !restrictionTypes[args.Type] {
+		return nil, nil, fmt.Errorf("invalid restriction type %q", args.Type)
+	}
+	matcher, err := parseMatcher(args.MatcherType, args.Matcher, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := s.getOpts(ctx, req)
+	restriction, err := s.client.CreateBranchRestriction(ctx, projectKey, args.Repository, bitbucket.CreateRestrictionRequest{
+		Type:    args.Type,
+		Matcher: matcher,
+		Users:   nonNil(args.ExemptUsers),
+		Groups:  nonNil(args.ExemptGroups),
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(restriction)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type deleteBranchRestrictionArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for a project restriction"`
+	RestrictionID int    `json:"restrictionId" jsonschema:"required"`
+}
+
+func (s *Server) deleteBranchRestriction(ctx context.Context, req *mcp.CallToolRequest, args deleteBranchRestrictionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.DeleteBranchRestriction(ctx, projectKey, args.Repository, args.RestrictionID, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "deleted"}}}, nil, nil
+}
+
+// parseMatcher builds a ref matcher from tool arguments. matcherType defaults to BRANCH;
+// ANY_REF is only accepted when allowAny is set and needs no value.
+func parseMatcher(matcherType, value string, allowAny bool) (bitbucket.RefMatcher, error) {
+	t := strings.ToUpper(matcherType)
+	if t == "" {
+		t = "BRANCH"
+	}
+	if t == "ANY_REF" && allowAny {
+		return bitbucket.NewRefMatcher(t, ""), nil
+	}
+	if !matcherTypes[t] {
+		return bitbucket.RefMatcher{}, fmt.Errorf("invalid matcher type %q", matcherType)
+	}
+	if value == "" {
+		return bitbucket.RefMatcher{}, fmt.Errorf("matcher value required for %s", t)
+	}
+	return bitbucket.NewRefMatcher(t, value), nil
+}
+
+// nonNil returns s, or an empty slice when s is nil, so it encodes as [] rather than null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
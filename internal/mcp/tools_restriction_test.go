@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const repoRestrictionsPath = "/rest/branch-permissions/2.0/projects/PROJ/repos/repo/restrictions"
+
+func TestListBranchRestrictions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoRestrictionsPath, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "no-deletes" {
+			t.Errorf("type = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":1,"type":"no-deletes","matcher":{"id":"refs/heads/main","type":{"id":"BRANCH"}},"users":[],"groups":[]}],"size":1,"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listBranchRestrictions(context.Background(), &sdkmcp.CallToolRequest{}, listBranchRestrictionsArgs{Repository: "repo", Type: "no-deletes"})
+	if err != nil {
+		t.Fatalf("listBranchRestrictions: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"type":"no-deletes"`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestListBranchRestrictions_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listBranchRestrictions(context.Background(), &sdkmcp.CallToolRequest{}, listBranchRestrictionsArgs{}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.listBranchRestrictions(context.Background(), &sdkmcp.CallToolRequest{}, listBranchRestrictionsArgs{}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestCreateBranchRestriction(t *testing.T) {
+	var got bitbucket.CreateRestrictionRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoRestrictionsPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":5,"type":"pull-request-only","matcher":{"id":"refs/heads/main","type":{"id":"BRANCH"}}}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, createBranchRestrictionArgs{
+		Repository: "repo", Type: "pull-request-only", Matcher: "main", ExemptGroups: []string{"release-managers"},
+	})
+	if err != nil {
+		t.Fatalf("createBranchRestriction: %v", err)
+	}
+	if got.Matcher.Type.ID != "BRANCH" || got.Matcher.ID != "refs/heads/main" {
+		t.Errorf("matcher = %+v", got.Matcher)
+	}
+	if got.Users == nil || len(got.Users) != 0 || got.Groups[0] != "release-managers" {
+		t.Errorf("exemptions = %v %v", got.Users, got.Groups)
+	}
+}
+
+func TestCreateBranchRestriction_Pattern(t *testing.T) {
+	var got bitbucket.CreateRestrictionRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/branch-permissions/2.0/projects/PROJ/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":6}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, createBranchRestrictionArgs{
+		Type: "read-only", MatcherType: "pattern", Matcher: "release/*",
+	})
+	if err != nil {
+		t.Fatalf("createBranchRestriction: %v", err)
+	}
+	if got.Matcher.Type.ID != "PATTERN" || got.Matcher.ID != "release/*" {
+		t.Errorf("matcher = %+v", got.Matcher)
+	}
+}
+
+func TestCreateBranchRestriction_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoRestrictionsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		args createBranchRestrictionArgs
+	}{
+		{"api error", createBranchRestrictionArgs{Repository: "repo", Type: "no-deletes", Matcher: "main"}},
+		{"bad type", createBranchRestrictionArgs{Repository: "repo", Type: "no-pushes", Matcher: "main"}},
+		{"bad matcher type", createBranchRestrictionArgs{Repository: "repo", Type: "no-deletes", MatcherType: "TAG", Matcher: "v1"}},
+		{"no matcher", createBranchRestrictionArgs{Repository: "repo", Type: "no-deletes"}},
+	}
+	for _, tt := range tests {
+		if _, _, err := srv.createBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, tt.args); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.createBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, tests[0].args); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestDeleteBranchRestriction(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoRestrictionsPath+"/5", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, deleteBranchRestrictionArgs{Repository: "repo", RestrictionID: 5}); err != nil {
+		t.Fatalf("deleteBranchRestriction: %v", err)
+	}
+}
+
+func TestDeleteBranchRestriction_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoRestrictionsPath+"/5", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, deleteBranchRestrictionArgs{Repository: "repo", RestrictionID: 5}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.deleteBranchRestriction(context.Background(), &sdkmcp.CallToolRequest{}, deleteBranchRestrictionArgs{RestrictionID: 5}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerReviewerTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_default_reviewer_conditions",
+		Description: "List default reviewer conditions of a repository (including inherited project ones), or of a project when repository is omitted",
+	}, s.listReviewerConditions)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_create_default_reviewer_condition",
+		Description: "Add default reviewers for pull requests matching a source and target ref, with a required approval count (requires admin)",
+	}, s.createReviewerCondition)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_delete_default_reviewer_condition",
+		Description: "Delete a default reviewer condition by ID (requires admin)",
+	}, s.deleteReviewerCondition)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_effective_default_reviewers",
+		Description: "Get the default reviewers Bitbucket would add to a pull request from a source branch to a target branch",
+	}, s.getEffectiveReviewers)
+}
+
+type listReviewerConditionsArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for project conditions"`
+}
+
+func (s *Server) listReviewerConditions(ctx context.Context, req *mcp.CallToolRequest, args listReviewerConditionsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	conditions, err := s.client.ListReviewerConditions(ctx, projectKey, args.Repository, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type createReviewerConditionArgs struct {
+	WorkspaceSlug     string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository        string   `json:"repository" jsonschema:"Repository slug; omit for a project condition"`
+	SourceMatcherType string   `json:"sourceMatcherType" jsonschema:"ANY_REF (default when sourceMatcher is empty), BRANCH, PATTERN, MODEL_CATEGORY or MODEL_BRANCH"`
+	SourceMatcher     string   `json:"sourceMatcher" jsonschema:"Source branch, pattern or category"`
+	TargetMatcherType string   `json:"targetMatcherType" jsonschema:"ANY_REF (default when targetMatcher is empty), BRANCH, PATTERN, MODEL_CATEGORY or MODEL_BRANCH"`
+	TargetMatcher     string   `json:"targetMatcher" jsonschema:"Target branch, pattern or category"`
+	Reviewers         []string `json:"reviewers" jsonschema:"required,Usernames (slugs) to add as reviewers"`
+	RequiredApprovals int      `json:"requiredApprovals" jsonschema:"How many of the reviewers must approve (default 0)"`
+}
+
+func (s *Server) createReviewerCondition(ctx context.Context, req *mcp.CallToolRequest, args createReviewerConditionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if len(args.Reviewers) == 0 {
+		return nil, nil, fmt.Errorf("reviewers required")
+	}
+	if args.RequiredApprovals < 0 || args.RequiredApprovals > len(args.Reviewers) {
+		return nil, nil, fmt.Errorf("requiredApprovals must be between 0 and the number of reviewers (%d)", len(args.Reviewers))
+	}
+	source, err := parseMatcher(defaultAnyRef(args.SourceMatcherType, args.SourceMatcher), args.SourceMatcher, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source: %w", err)
+	}
+	target, err := parseMatcher(defaultAnyRef(args.TargetMatcherType, args.TargetMatcher), args.TargetMatcher, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("target: %w", err)
+	}
+	opts := s.getOpts(ctx, req)
+	// The API takes user IDs, so resolve each username first.
+	reviewers := make([]bitbucket.ReviewerRef, 0, len(args.Reviewers))
+	for _, name := range args.Reviewers {
+		user, err := s.client.GetUser(ctx, name, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reviewer %s: %w", name, err)
+		}
+		reviewers = append(reviewers, bitbucket.ReviewerRef{ID: user.ID})
+	}
+	condition, err := s.client.CreateReviewerCondition(ctx, projectKey, args.Repository, bitbucket.CreateReviewerConditionRequest{
+		SourceMatcher:     source,
+		TargetMatcher:     target,
+		Reviewers:         reviewers,
+		RequiredApprovals: args.RequiredApprovals,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(condition)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// defaultAnyRef returns ANY_REF when neither a matcher type nor a value was given.
+func defaultAnyRef(matcherType, value string) string {
+	if matcherType == "" && value == "" {
+		return "ANY_REF"
+	}
+	return matcherType
+}
+
+type deleteReviewerConditionArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for a project condition"`
+	ConditionID   int    `json:"conditionId" jsonschema:"required"`
+}
+
+func (s *Server) deleteReviewerCondition(ctx context.Context, req *mcp.CallToolRequest, args deleteReviewerConditionArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.DeleteReviewerCondition(ctx, projectKey, args.Repository, args.ConditionID, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "deleted"}}}, nil, nil
+}
+
+type effectiveReviewersArgs struct {
+	WorkspaceSlug       string `json:"workspaceSlug" jsonschema:"Project key of the target repository (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository          string `json:"repository" jsonschema:"required,Target repository slug"`
+	SourceBranch        string `json:"sourceBranch" jsonschema:"required"`
+	TargetBranch        string `json:"targetBranch" jsonschema:"required"`
+	SourceWorkspaceSlug string `json:"sourceWorkspaceSlug" jsonschema:"Project key of the source repository when it is a fork (default: target project)"`
+	SourceRepository    string `json:"sourceRepository" jsonschema:"Source repository slug when it is a fork (default: target repository)"`
+}
+
+func (s *Server) getEffectiveReviewers(ctx context.Context, req *mcp.CallToolRequest, args effectiveReviewersArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.Repository == "" || args.SourceBranch == "" || args.TargetBranch == "" {
+		return nil, nil, fmt.Errorf("repository, sourceBranch and targetBranch required")
+	}
+	opts := s.getOpts(ctx, req)
+	target, err := s.client.GetRepository(ctx, projectKey, args.Repository, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	source := target
+	if args.SourceWorkspaceSlug != "" || args.SourceRepository != "" {
+		sourceProject := args.SourceWorkspaceSlug
+		if sourceProject == "" {
+			sourceProject = projectKey
+		}
+		sourceRepo := args.SourceRepository
+		if sourceRepo == "" {
+			sourceRepo = args.Repository
+		}
+		if source, err = s.client.GetRepository(ctx, sourceProject, sourceRepo, opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	reviewers, err := s.client.GetEffectiveReviewers(ctx, projectKey, args.Repository, bitbucket.ReviewerPair{
+		SourceRepoID: source.ID,
+		SourceRefID:  bitbucket.NewRefMatcher("BRANCH", args.SourceBranch).ID,
+		TargetRepoID: target.ID,
+		TargetRefID:  bitbucket.NewRefMatcher("BRANCH", args.TargetBranch).ID,
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reviewers == nil {
+		reviewers = []bitbucket.User{}
+	}
+	data, err := json.Marshal(reviewers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const reviewersBase = "/rest/default-reviewers/1.0/projects/PROJ/repos/repo"
+
+func userHandler(id int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"name":"u","id":%d}`, id)
+	}
+}
+
+func TestListReviewerConditions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(reviewersBase+"/conditions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":4,"requiredApprovals":1,"reviewers":[{"name":"alice"}]}]`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listReviewerConditions(context.Background(), &sdkmcp.CallToolRequest{}, listReviewerConditionsArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("listReviewerConditions: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"requiredApprovals":1`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestListReviewerConditions_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/default-reviewers/1.0/projects/PROJ/conditions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listReviewerConditions(context.Background(), &sdkmcp.CallToolRequest{}, listReviewerConditionsArgs{}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.listReviewerConditions(context.Background(), &sdkmcp.CallToolRequest{}, listReviewerConditionsArgs{}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestCreateReviewerCondition(t *testing.T) {
+	var got bitbucket.CreateReviewerConditionRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/alice", userHandler(1))
+	mux.HandleFunc("/rest/api/1.0/users/bob", userHandler(2))
+	mux.HandleFunc(reviewersBase+"/condition", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":4}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, createReviewerConditionArgs{
+		Repository: "repo", TargetMatcher: "main", Reviewers: []string{"alice", "bob"}, RequiredApprovals: 2,
+	})
+	if err != nil {
+		t.Fatalf("createReviewerCondition: %v", err)
+	}
+	if got.SourceMatcher.Type.ID != "ANY_REF" || got.TargetMatcher.ID != "refs/heads/main" || got.TargetMatcher.Type.ID != "BRANCH" {
+		t.Errorf("matchers = %+v / %+v", got.SourceMatcher, got.TargetMatcher)
+	}
+	if len(got.Reviewers) != 2 || got.Reviewers[0].ID != 1 || got.Reviewers[1].ID != 2 || got.RequiredApprovals != 2 {
+		t.Errorf("reviewers = %+v approvals = %d", got.Reviewers, got.RequiredApprovals)
+	}
+}
+
+func TestCreateReviewerCondition_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/alice", userHandler(1))
+	mux.HandleFunc("/rest/api/1.0/users/ghost", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	mux.HandleFunc(reviewersBase+"/condition", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		args createReviewerConditionArgs
+	}{
+		{"api error", createReviewerConditionArgs{Repository: "repo", Reviewers: []string{"alice"}}},
+		{"no reviewers", createReviewerConditionArgs{Repository: "repo"}},
+		{"too many approvals", createReviewerConditionArgs{Repository: "repo", Reviewers: []string{"alice"}, RequiredApprovals: 2}},
+		{"bad source", createReviewerConditionArgs{Repository: "repo", Reviewers: []string{"alice"}, SourceMatcherType: "TAG", SourceMatcher: "v1"}},
+		{"bad target", createReviewerConditionArgs{Repository: "repo", Reviewers: []string{"alice"}, TargetMatcherType: "PATTERN"}},
+		{"unknown user", createReviewerConditionArgs{Repository: "repo", Reviewers: []string{"ghost"}}},
+	}
+	for _, tt := range tests {
+		if _, _, err := srv.createReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, tt.args); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.createReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, tests[0].args); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestDeleteReviewerCondition(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(reviewersBase+"/condition/4", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, deleteReviewerConditionArgs{Repository: "repo", ConditionID: 4}); err != nil {
+		t.Fatalf("deleteReviewerCondition: %v", err)
+	}
+}
+
+func TestDeleteReviewerCondition_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(reviewersBase+"/condition/4", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, deleteReviewerConditionArgs{Repository: "repo", ConditionID: 4}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.deleteReviewerCondition(context.Background(), &sdkmcp.CallToolRequest{}, deleteReviewerConditionArgs{ConditionID: 4}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestGetEffectiveReviewers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","id":1}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/~ALICE/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","id":2}`))
+	})
+	mux.HandleFunc(reviewersBase+"/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if q.Get("sourceRepoId") == "2" && q.Get("sourceRefId") == "refs/heads/feature" && q.Get("targetRefId") == "refs/heads/main" {
+			_, _ = w.Write([]byte(`[{"name":"alice","id":1}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getEffectiveReviewers(context.Background(), &sdkmcp.CallToolRequest{}, effectiveReviewersArgs{
+		Repository: "repo", SourceBranch: "feature", TargetBranch: "refs/heads/main", SourceWorkspaceSlug: "~ALICE",
+	})
+	if err != nil {
+		t.Fatalf("getEffectiveReviewers: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"name":"alice"`) {
+		t.Errorf("fork result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+
+	result, _, err = srv.getEffectiveReviewers(context.Background(), &sdkmcp.CallToolRequest{}, effectiveReviewersArgs{
+		Repository: "repo", SourceBranch: "feature", TargetBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("getEffectiveReviewers: %v", err)
+	}
+	if got := result.Content[0].(*sdkmcp.TextContent).Text; got != "[]" {
+		t.Errorf("same-repo result = %s", got)
+	}
+}
+
+func TestGetEffectiveReviewers_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"repo","id":1}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	mux.HandleFunc(reviewersBase+"/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		args effectiveReviewersArgs
+	}{
+		{"api error", effectiveReviewersArgs{Repository: "repo", SourceBranch: "a", TargetBranch: "b"}},
+		{"missing branch", effectiveReviewersArgs{Repository: "repo", SourceBranch: "a"}},
+		{"missing target repo", effectiveReviewersArgs{Repository: "missing", SourceBranch: "a", TargetBranch: "b"}},
+		{"missing source repo", effectiveReviewersArgs{Repository: "repo", SourceRepository: "missing", SourceBranch: "a", TargetBranch: "b"}},
+	}
+	for _, tt := range tests {
+		if _, _, err := srv.getEffectiveReviewers(context.Background(), &sdkmcp.CallToolRequest{}, tt.args); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getEffectiveReviewers(context.Background(), &sdkmcp.CallToolRequest{}, tests[0].args); err == nil {
+		t.Error("expected workspace error")
+	}
+}
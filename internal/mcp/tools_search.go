@@ -0,0 +1,296 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+// Entry types returned by bitbucket_search.
+const (
+	searchTypePullRequest = "pull_request"
+	searchTypeCommit      = "commit"
+	searchTypeRepository  = "repository"
+)
+
+const (
+	defaultSearchResults   = 20
+	defaultCommitDepth     = 100
+	maxCommitDepth         = 1000
+	searchConcurrency      = 4
+	maxSearchDescription   = 300
+	pullRequestSearchLimit = 25
+)
+
+func (s *Server) registerSearchTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name: "bitbucket_search",
+		Description: "Search pull request titles and descriptions and recent commit messages of a project, and repository " +
+			"names across all projects, in one call. Returns one list ranked by relevance, each entry typed pull_request, commit or repository. " +
+			"Use bitbucket_search_content to search code.",
+	}, s.search)
+}
+
+type searchArgs struct {
+	Query         string   `json:"query" jsonschema:"required,Text to look for, e.g. a feature flag name"`
+	WorkspaceSlug string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string   `json:"repository" jsonschema:"Only search this repository (default: the project's first 25 repositories)"`
+	Types         []string `json:"types" jsonschema:"Entry types to search: pull_request, commit and/or repository (default: all)"`
+	PRState       string   `json:"prState" jsonschema:"Pull request state: OPEN, MERGED, DECLINED or ALL (default: ALL)"`
+	CommitDepth   int      `json:"commitDepth" jsonschema:"Recent commits scanned per repository on the default branch (default: 100, max: 1000)"`
+	Limit         int      `json:"limit" jsonschema:"Maximum entries returned (default: 20)"`
+}
+
+// searchEntry is one typed bitbucket_search result.
+type searchEntry struct {
+	Type        string  `json:"type"`
+	Score       float64 `json:"score"`
+	Project     string  `json:"project"`
+	Repository  string  `json:"repository"`
+	ID          string  `json:"id"` // pull request ID, commit hash or repository slug
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Author      string  `json:"author,omitempty"`
+	Date        int64   `json:"date,omitempty"` // epoch milliseconds
+}
+
+type searchResult struct {
+	Query   string        `json:"query"`
+	Results []searchEntry `json:"results"`
+	// Warnings lists repositories that could not be searched, or were not reached.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (s *Server) search(ctx context.Context, req *mcp.CallToolRequest, args searchArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		return nil, nil, fmt.Errorf("query is required")
+	}
+	types := args.Types
+	if len(types) == 0 {
+		types = []string{searchTypePullRequest, searchTypeCommit, searchTypeRepository}
+	}
+	for _, t := range types {
+		if t != searchTypePullRequest && t != searchTypeCommit && t != searchTypeRepository {
+			return nil, nil, fmt.Errorf("unknown type %q: use pull_request, commit or repository", t)
+		}
+	}
+	prState := strings.ToUpper(args.PRState)
+	if prState == "" {
+		prState = "ALL"
+	}
+	depth := min(args.CommitDepth, maxCommitDepth)
+	if depth <= 0 {
+		depth = defaultCommitDepth
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+	opts := s.getOpts(ctx, req)
+
+	out := searchResult{Query: query, Results: []searchEntry{}}
+	if slices.Contains(types, searchTypeRepository) {
+		// Repository names are matched by Bitbucket across every project, not just projectKey.
+		resp, err := s.client.SearchRepositories(ctx, bitbucket.RepoSearchOptions{Name: query, Limit: limit}, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range resp.Values {
+			if args.Repository != "" && r.Slug != args.Repository {
+				continue
+			}
+			project := projectKey
+			if r.Project != nil {
+				project = r.Project.Key
+			}
+			if score := relevance(query, r.Name, r.Slug+" "+r.Description); score > 0 {
+				out.Results = append(out.Results, searchEntry{
+					Type: searchTypeRepository, Score: score, Project: project, Repository: r.Slug,
+					ID: r.Slug, Title: r.Name, Description: truncateText(r.Description, maxSearchDescription),
+				})
+			}
+		}
+	}
+
+	var repos []bitbucket.Repository
+	switch {
+	case !slices.Contains(types, searchTypePullRequest) && !slices.Contains(types, searchTypeCommit):
+	case args.Repository != "":
+		repos = []bitbucket.Repository{{Slug: args.Repository}}
+	default:
+		resp, err := s.client.ListRepositories(ctx, projectKey, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		repos = resp.Values
+		if !resp.IsLastPage {
+			out.Warnings = append(out.Warnings, fmt.Sprintf(
+				"only the first %d repositories of %s were searched for pull requests and commits; pass repository to search another",
+				len(repos), projectKey))
+		}
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, searchConcurrency)
+	)
+	collect := func(repo string, entries []searchEntry, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			out.Warnings = append(out.Warnings, repo+": "+err.Error())
+			return
+		}
+		out.Results = append(out.Results, entries...)
+	}
+	for _, r := range repos {
+		if slices.Contains(types, searchTypePullRequest) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				entries, err := s.searchPullRequests(ctx, projectKey, r.Slug, query, prState, opts)
+				collect(r.Slug, entries, err)
+			}()
+		}
+		if slices.Contains(types, searchTypeCommit) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				entries, err := s.searchCommits(ctx, projectKey, r.Slug, query, depth, opts)
+				collect(r.Slug, entries, err)
+			}()
+		}
+	}
+	wg.Wait()
+
+	sort.SliceStable(out.Results, func(i, j int) bool {
+		a, b := out.Results[i], out.Results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Date > b.Date
+	})
+	if len(out.Results) > limit {
+		out.Results = out.Results[:limit]
+	}
+	sort.Strings(out.Warnings)
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// searchPullRequests finds pull requests with Bitbucket's filterText, which matches
+// titles, descriptions and branch names.
+func (s *Server) searchPullRequests(ctx context.Context, projectKey, repo, query, state string, opts bitbucket.RequestOpts) ([]searchEntry, error) {
+	resp, err := s.client.ListPullRequests(ctx, projectKey, repo, bitbucket.PullRequestListOptions{
+		State: state, FilterText: query, Limit: pullRequestSearchLimit,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entries []searchEntry
+	for _, pr := range resp.Values {
+		// Bitbucket matched it, possibly on a branch name we do not score.
+		score := max(relevance(query, pr.Title, pr.Description), 0.1)
+		entry := searchEntry{
+			Type: searchTypePullRequest, Score: score, Project: projectKey, Repository: repo,
+			ID: strconv.Itoa(pr.ID), Title: pr.Title, Description: truncateText(pr.Description, maxSearchDescription),
+			State: pr.State, Date: pr.UpdatedDate,
+		}
+		if pr.Author != nil {
+			entry.Author = pr.Author.Name
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// searchCommits matches query against the messages of the most recent depth commits
+// on the default branch; Bitbucket Data Center has no commit message search.
+func (s *Server) searchCommits(ctx context.Context, projectKey, repo, query string, depth int, opts bitbucket.RequestOpts) ([]searchEntry, error) {
+	var entries []searchEntry
+	for start := 0; start < depth; {
+		resp, err := s.client.ListCommits(ctx, projectKey, repo, "", "", start, min(depth-start, 100), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range resp.Values {
+			subject, body, _ := strings.Cut(c.Message, "\n")
+			score := relevance(query, subject, body)
+			if score == 0 {
+				continue
+			}
+			entry := searchEntry{
+				Type: searchTypeCommit, Score: score, Project: projectKey, Repository: repo,
+				ID: c.ID, Title: subject, Description: truncateText(strings.TrimSpace(body), maxSearchDescription),
+				Date: c.AuthorTimestamp,
+			}
+			if c.Author != nil {
+				entry.Author = c.Author.Name
+			}
+			entries = append(entries, entry)
+		}
+		if resp.IsLastPage || resp.NextPageStart <= start {
+			break
+		}
+		start = resp.NextPageStart
+	}
+	return entries, nil
+}
+
+// relevance scores how well query matches an entry's primary text (title, name, commit
+// subject) and secondary text (description, message body), from 0 (no match) to 1.
+func relevance(query, primary, secondary string) float64 {
+	q := strings.ToLower(query)
+	p, s := strings.ToLower(primary), strings.ToLower(secondary)
+	switch {
+	case p == q:
+		return 1
+	case strings.Contains(p, q):
+		return 0.8
+	case strings.Contains(s, q):
+		return 0.6
+	}
+	words := strings.Fields(q)
+	found := 0
+	for _, w := range words {
+		if strings.Contains(p, w) || strings.Contains(s, w) {
+			found++
+		}
+	}
+	if len(words) == 0 {
+		return 0
+	}
+	return math.Round(50*float64(found)/float64(len(words))) / 100
+}
+
+// truncateText shortens s to at most n runes, marking the cut with an ellipsis.
+func truncateText(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
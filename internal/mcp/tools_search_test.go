@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func searchMux(t *testing.T) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"slug":"flags","name":"flags","description":"Feature flag service"},{"slug":"web","name":"web"}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("name") != "feature flag" {
+			_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"slug":"flags","name":"Feature flags","project":{"key":"OPS"}},{"slug":"ff","name":"Feature flag","project":{"key":"PROJ"}}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/web/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("filterText") != "dark mode" || q.Get("state") != "ALL" {
+			t.Errorf("pull-requests query = %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[
+			{"id":12,"title":"Add dark mode flag","description":"Behind FF","state":"MERGED","updatedDate":200},
+			{"id":15,"title":"Refactor theme","description":"Prepares dark mode","state":"OPEN","updatedDate":300}],"isLastPage":true}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/web/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[
+			{"id":"c1","message":"Dark mode\n\nFinal wiring","author":{"name":"alice"},"authorTimestamp":100},
+			{"id":"c2","message":"Unrelated fix","authorTimestamp":400}],"isLastPage":true}`))
+	})
+	// flags has no pull requests endpoint and an empty commit history.
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/flags/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[],"isLastPage":true}`))
+	})
+	return mux
+}
+
+func runSearch(t *testing.T, srv *Server, args searchArgs) searchResult {
+	t.Helper()
+	result, _, err := srv.search(context.Background(), &sdkmcp.CallToolRequest{}, args)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	var out searchResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*sdkmcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	srv, ts := bbServer(searchMux(t))
+	defer ts.Close()
+
+	out := runSearch(t, srv, searchArgs{Query: "dark mode"})
+	var got []string
+	for _, e := range out.Results {
+		got = append(got, e.Type+":"+e.ID)
+	}
+	// Exact commit subject, then the PR title match, then the PR description match.
+	if want := "commit:c1 pull_request:12 pull_request:15"; strings.Join(got, " ") != want {
+		t.Errorf("results = %v, want %s", got, want)
+	}
+	if out.Results[0].Author != "alice" || out.Results[0].Title != "Dark mode" || out.Results[0].Description != "Final wiring" {
+		t.Errorf("commit entry = %+v", out.Results[0])
+	}
+	if len(out.Warnings) != 1 || !strings.HasPrefix(out.Warnings[0], "flags: ") {
+		t.Errorf("warnings = %v", out.Warnings)
+	}
+}
+
+func TestSearch_RepositoriesAndLimit(t *testing.T) {
+	srv, ts := bbServer(searchMux(t))
+	defer ts.Close()
+
+	out := runSearch(t, srv, searchArgs{Query: "feature flag", Types: []string{"repository"}})
+	var got []string
+	for _, e := range out.Results {
+		got = append(got, e.Project+"/"+e.ID)
+	}
+	// Exact name first; other projects are searched too.
+	if want := "PROJ/ff OPS/flags"; strings.Join(got, " ") != want {
+		t.Errorf("results = %v, want %s", got, want)
+	}
+
+	out = runSearch(t, srv, searchArgs{Query: "dark mode", Repository: "web", Types: []string{"pull_request"}, Limit: 1})
+	if len(out.Results) != 1 || out.Results[0].ID != "12" || len(out.Warnings) != 0 {
+		t.Errorf("out = %+v", out)
+	}
+}
+
+func TestSearch_MoreRepositoriesWarns(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"slug":"web","name":"web"}],"isLastPage":false,"nextPageStart":1}`))
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/web/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"id":"c1","message":"Dark mode"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	out := runSearch(t, srv, searchArgs{Query: "dark mode", Types: []string{"commit"}})
+	if len(out.Results) != 1 || out.Results[0].ID != "c1" {
+		t.Errorf("results = %+v", out.Results)
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "first 1 repositories of PROJ") || !strings.Contains(out.Warnings[0], "pass repository") {
+		t.Errorf("warnings = %v", out.Warnings)
+	}
+}
+
+func TestSearch_Errors(t *testing.T) {
+	srv, ts := bbServer(searchMux(t))
+	defer ts.Close()
+
+	for name, args := range map[string]searchArgs{
+		"empty query":  {Query: " "},
+		"unknown type": {Query: "x", Types: []string{"issue"}},
+	} {
+		if _, _, err := srv.search(context.Background(), &sdkmcp.CallToolRequest{}, args); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.search(context.Background(), &sdkmcp.CallToolRequest{}, searchArgs{Query: "x"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestRelevance(t *testing.T) {
+	tests := []struct {
+		primary, secondary string
+		want               float64
+	}{
+		{"Dark Mode", "", 1},
+		{"Add dark mode", "", 0.8},
+		{"Theme", "adds dark mode", 0.6},
+		{"Dark theme", "", 0.25},
+		{"Unrelated", "", 0},
+	}
+	for _, tt := range tests {
+		if got := relevance("dark mode", tt.primary, tt.secondary); got != tt.want {
+			t.Errorf("relevance(%q, %q) = %v, want %v", tt.primary, tt.secondary, got, tt.want)
+		}
+	}
+}
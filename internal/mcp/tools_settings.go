@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerSettingsTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_pull_request_settings",
+		Description: "Get a repository's merge checks (required approvers, successful builds, resolved tasks) and merge strategies",
+	}, s.getPullRequestSettings)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_update_pull_request_settings",
+		Description: "Update a repository's merge checks and enabled merge strategies; omitted fields are unchanged (requires repo admin)",
+	}, s.updatePullRequestSettings)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_hooks",
+		Description: "List pre-receive, post-receive and merge check hooks of a repository (or project) and whether each is enabled",
+	}, s.listHooks)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_set_hook_enabled",
+		Description: "Enable or disable a repository (or project) hook by key (requires admin)",
+	}, s.setHookEnabled)
+}
+
+type pullRequestSettingsArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"required"`
+}
+
+func (s *Server) getPullRequestSettings(ctx context.Context, req *mcp.CallToolRequest, args pullRequestSettingsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	settings, err := s.client.GetPullRequestSettings(ctx, projectKey, args.Repository, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type updatePullRequestSettingsArgs struct {
+	WorkspaceSlug            string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository               string   `json:"repository" jsonschema:"required"`
+	RequiredApprovers        *int     `json:"requiredApprovers" jsonschema:"Minimum number of approvals (0 disables the check)"`
+	RequiredAllApprovers     *bool    `json:"requiredAllApprovers" jsonschema:"Require every reviewer to approve"`
+	RequiredAllTasksComplete *bool    `json:"requiredAllTasksComplete" jsonschema:"Require all tasks to be resolved"`
+	RequiredSuccessfulBuilds *int     `json:"requiredSuccessfulBuilds" jsonschema:"Minimum number of successful builds (0 disables the check)"`
+	MergeStrategies          []string `json:"mergeStrategies" jsonschema:"Enabled strategies: no-ff, ff, ff-only, squash, squash-ff-only, rebase-no-ff, rebase-ff-only"`
+	DefaultMergeStrategy     string   `json:"defaultMergeStrategy" jsonschema:"Default strategy; must be enabled"`
+}
+
+func (s *Server) updatePullRequestSettings(ctx context.Context, req *mcp.CallToolRequest, args updatePullRequestSettingsArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	settings := bitbucket.PullRequestSettings{
+		RequiredApprovers:        args.RequiredApprovers,
+		RequiredAllApprovers:     args.RequiredAllApprovers,
+		RequiredAllTasksComplete: args.RequiredAllTasksComplete,
+		RequiredSuccessfulBuilds: args.RequiredSuccessfulBuilds,
+	}
+	if len(args.MergeStrategies) > 0 || args.DefaultMergeStrategy != "" {
+		mergeConfig, err := s.mergeConfig(ctx, projectKey, args, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		settings.MergeConfig = mergeConfig
+	}
+	updated, err := s.client.UpdatePullRequestSettings(ctx, projectKey, args.Repository, settings, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(updated)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+// mergeConfig builds the merge config for an update. Bitbucket replaces the whole config, so
+// when only a default is given the currently enabled strategies are kept.
+func (s *Server) mergeConfig(ctx context.Context, projectKey string, args updatePullRequestSettingsArgs, opts bitbucket.RequestOpts) (*bitbucket.MergeConfig, error) {
+	ids := args.MergeStrategies
+	if len(ids) == 0 {
+		current, err := s.client.GetPullRequestSettings(ctx, projectKey, args.Repository, opts)
+		if err != nil {
+			return nil, err
+		}
+		if current.MergeConfig != nil {
+			for _, st := range current.MergeConfig.Strategies {
+				if st.Enabled {
+					ids = append(ids, st.ID)
+				}
+			}
+		}
+	}
+	defaultID := args.DefaultMergeStrategy
+	if defaultID == "" {
+		defaultID = ids[0]
+	}
+	if !slices.Contains(ids, defaultID) {
+		return nil, fmt.Errorf("defaultMergeStrategy %q is not an enabled strategy %v", defaultID, ids)
+	}
+	config := &bitbucket.MergeConfig{DefaultStrategy: &bitbucket.MergeStrategy{ID: defaultID}}
+	for _, id := range ids {
+		config.Strategies = append(config.Strategies, bitbucket.MergeStrategy{ID: id})
+	}
+	return config, nil
+}
+
+type listHooksArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for project hooks"`
+	Type          string `json:"type" jsonschema:"Only PRE_RECEIVE, POST_RECEIVE or PRE_PULL_REQUEST_MERGE hooks"`
+	Start         int    `json:"start" jsonschema:"Page start (from nextPageStart)"`
+}
+
+func (s *Server) listHooks(ctx context.Context, req *mcp.CallToolRequest, args listHooksArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	hooks, err := s.client.ListHooks(ctx, projectKey, args.Repository, args.Type, args.Start, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(hooks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type setHookEnabledArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for a project hook"`
+	HookKey       string `json:"hookKey" jsonschema:"required,Hook key from bitbucket_list_hooks, e.g. com.atlassian.bitbucket.server.bitbucket-bundled-hooks:force-push-hook"`
+	Enabled       bool   `json:"enabled" jsonschema:"required"`
+}
+
+func (s *Server) setHookEnabled(ctx context.Context, req *mcp.CallToolRequest, args setHookEnabledArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.HookKey == "" {
+		return nil, nil, fmt.Errorf("hookKey required")
+	}
+	opts := s.getOpts(ctx, req)
+	hook, err := s.client.SetHookEnabled(ctx, projectKey, args.Repository, args.HookKey, args.Enabled, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -0,0 +1,219 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const (
+	prSettingsPath = "/rest/api/1.0/projects/PROJ/repos/repo/settings/pull-requests"
+	prSettingsJSON = `{"requiredApprovers":1,"mergeConfig":{"defaultStrategy":{"id":"no-ff"},"strategies":[{"id":"no-ff","enabled":true},{"id":"squash","enabled":true},{"id":"ff","enabled":false}]}}`
+)
+
+// settingsServer serves the current settings on GET and records the last update body.
+func settingsServer(t *testing.T, got *bitbucket.PullRequestSettings) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(prSettingsJSON))
+	})
+	return mux
+}
+
+func TestGetPullRequestSettings(t *testing.T) {
+	srv, ts := bbServer(settingsServer(t, nil))
+	defer ts.Close()
+
+	result, _, err := srv.getPullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, pullRequestSettingsArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("getPullRequestSettings: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"requiredApprovers":1`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestGetPullRequestSettings_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getPullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, pullRequestSettingsArgs{Repository: "repo"}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.getPullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, pullRequestSettingsArgs{Repository: "repo"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestUpdatePullRequestSettings_Checks(t *testing.T) {
+	var got bitbucket.PullRequestSettings
+	srv, ts := bbServer(settingsServer(t, &got))
+	defer ts.Close()
+
+	two, yes := 2, true
+	_, _, err := srv.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{
+		Repository: "repo", RequiredApprovers: &two, RequiredAllTasksComplete: &yes,
+	})
+	if err != nil {
+		t.Fatalf("updatePullRequestSettings: %v", err)
+	}
+	if *got.RequiredApprovers != 2 || !*got.RequiredAllTasksComplete || got.RequiredSuccessfulBuilds != nil || got.MergeConfig != nil {
+		t.Errorf("sent = %+v", got)
+	}
+}
+
+func TestUpdatePullRequestSettings_Strategies(t *testing.T) {
+	var got bitbucket.PullRequestSettings
+	srv, ts := bbServer(settingsServer(t, &got))
+	defer ts.Close()
+
+	_, _, err := srv.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{
+		Repository: "repo", MergeStrategies: []string{"squash", "ff-only"},
+	})
+	if err != nil {
+		t.Fatalf("updatePullRequestSettings: %v", err)
+	}
+	mc := got.MergeConfig
+	if mc == nil || mc.DefaultStrategy.ID != "squash" || len(mc.Strategies) != 2 || mc.Strategies[1].ID != "ff-only" {
+		t.Errorf("mergeConfig = %+v", mc)
+	}
+}
+
+func TestUpdatePullRequestSettings_DefaultKeepsEnabled(t *testing.T) {
+	var got bitbucket.PullRequestSettings
+	srv, ts := bbServer(settingsServer(t, &got))
+	defer ts.Close()
+
+	_, _, err := srv.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{
+		Repository: "repo", DefaultMergeStrategy: "squash",
+	})
+	if err != nil {
+		t.Fatalf("updatePullRequestSettings: %v", err)
+	}
+	mc := got.MergeConfig
+	if mc.DefaultStrategy.ID != "squash" || len(mc.Strategies) != 2 || mc.Strategies[0].ID != "no-ff" {
+		t.Errorf("mergeConfig = %+v", mc)
+	}
+}
+
+func TestUpdatePullRequestSettings_Errors(t *testing.T) {
+	srv, ts := bbServer(settingsServer(t, &bitbucket.PullRequestSettings{}))
+	defer ts.Close()
+
+	// ff is disabled in the current settings, so it cannot become the default.
+	if _, _, err := srv.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{
+		Repository: "repo", DefaultMergeStrategy: "ff",
+	}); err == nil {
+		t.Error("expected error for disabled default strategy")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(prSettingsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	})
+	srv2, ts2 := bbServer(mux)
+	defer ts2.Close()
+	if _, _, err := srv2.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{Repository: "repo"}); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv2.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{Repository: "repo", DefaultMergeStrategy: "squash"}); err == nil {
+		t.Error("expected error reading current strategies")
+	}
+	srv2.defaultProjectKey = ""
+	if _, _, err := srv2.updatePullRequestSettings(context.Background(), &sdkmcp.CallToolRequest{}, updatePullRequestSettingsArgs{Repository: "repo"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestListHooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/settings/hooks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"details":{"key":"k","name":"Hook","type":"PRE_PULL_REQUEST_MERGE"},"enabled":false}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listHooks(context.Background(), &sdkmcp.CallToolRequest{}, listHooksArgs{Repository: "repo"})
+	if err != nil {
+		t.Fatalf("listHooks: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"type":"PRE_PULL_REQUEST_MERGE"`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestListHooks_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/settings/hooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listHooks(context.Background(), &sdkmcp.CallToolRequest{}, listHooksArgs{}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.listHooks(context.Background(), &sdkmcp.CallToolRequest{}, listHooksArgs{}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestSetHookEnabled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/settings/hooks/k/enabled", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"details":{"key":"k"},"enabled":false}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.setHookEnabled(context.Background(), &sdkmcp.CallToolRequest{}, setHookEnabledArgs{Repository: "repo", HookKey: "k", Enabled: false})
+	if err != nil {
+		t.Fatalf("setHookEnabled: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"enabled":false`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestSetHookEnabled_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/repos/repo/settings/hooks/k/enabled", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.setHookEnabled(context.Background(), &sdkmcp.CallToolRequest{}, setHookEnabledArgs{Repository: "repo", HookKey: "k", Enabled: true}); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv.setHookEnabled(context.Background(), &sdkmcp.CallToolRequest{}, setHookEnabledArgs{Repository: "repo"}); err == nil {
+		t.Error("expected error without hookKey")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.setHookEnabled(context.Background(), &sdkmcp.CallToolRequest{}, setHookEnabledArgs{HookKey: "k"}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
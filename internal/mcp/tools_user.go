@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *Server) registerUserTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_search_users",
+		Description: "Find users by username, display name or email (use to resolve a person to a Bitbucket username)",
+	}, s.searchUsers)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_user",
+		Description: "Get a user by slug",
+	}, s.getUser)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_groups",
+		Description: "List group names, optionally filtered",
+	}, s.listGroups)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_group_members",
+		Description: "List users in a group (requires Bitbucket admin)",
+	}, s.getGroupMembers)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_get_user_groups",
+		Description: "List the groups a user belongs to (requires Bitbucket admin)",
+	}, s.getUserGroups)
+}
+
+type searchUsersArgs struct {
+	Filter string `json:"filter" jsonschema:"required,Text contained in the username, display name or email"`
+	Start  int    `json:"start" jsonschema:"Page start (nextPageStart from the previous page)"`
+	Limit  int    `json:"limit" jsonschema:"Page size"`
+}
+
+func (s *Server) searchUsers(ctx context.Context, req *mcp.CallToolRequest, args searchUsersArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	resp, err := s.client.SearchUsers(ctx, args.Filter, args.Start, args.Limit, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type getUserArgs struct {
+	UserSlug string `json:"userSlug" jsonschema:"required,User slug (usually the lower-cased username)"`
+}
+
+func (s *Server) getUser(ctx context.Context, req *mcp.CallToolRequest, args getUserArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	user, err := s.client.GetUser(ctx, args.UserSlug, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type listGroupsArgs struct {
+	Filter string `json:"filter" jsonschema:"Text contained in the group name"`
+	Start  int    `json:"start" jsonschema:"Page start (nextPageStart from the previous page)"`
+	Limit  int    `json:"limit" jsonschema:"Page size"`
+}
+
+func (s *Server) listGroups(ctx context.Context, req *mcp.CallToolRequest, args listGroupsArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	resp, err := s.client.ListGroups(ctx, args.Filter, args.Start, args.Limit, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type getGroupMembersArgs struct {
+	Group  string `json:"group" jsonschema:"required"`
+	Filter string `json:"filter" jsonschema:"Only members whose name contains this text"`
+	Start  int    `json:"start" jsonschema:"Page start (nextPageStart from the previous page)"`
+	Limit  int    `json:"limit" jsonschema:"Page size"`
+}
+
+func (s *Server) getGroupMembers(ctx context.Context, req *mcp.CallToolRequest, args getGroupMembersArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	resp, err := s.client.ListGroupMembers(ctx, args.Group, args.Filter, args.Start, args.Limit, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type getUserGroupsArgs struct {
+	Username string `json:"username" jsonschema:"required"`
+	Filter   string `json:"filter" jsonschema:"Only groups whose name contains this text"`
+	Start    int    `json:"start" jsonschema:"Page start (nextPageStart from the previous page)"`
+	Limit    int    `json:"limit" jsonschema:"Page size"`
+}
+
+func (s *Server) getUserGroups(ctx context.Context, req *mcp.CallToolRequest, args getUserGroupsArgs) (*mcp.CallToolResult, any, error) {
+	opts := s.getOpts(ctx, req)
+	resp, err := s.client.ListUserGroups(ctx, args.Username, args.Filter, args.Start, args.Limit, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSearchUsers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"asmith","displayName":"Alice Smith"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.searchUsers(context.Background(), &sdkmcp.CallToolRequest{}, searchUsersArgs{Filter: "alice"})
+	if err != nil {
+		t.Fatalf("searchUsers: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "asmith") {
+		t.Error("expected username in result")
+	}
+}
+
+func TestSearchUsers_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(401)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.searchUsers(context.Background(), &sdkmcp.CallToolRequest{}, searchUsersArgs{Filter: "alice"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/asmith", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"asmith","displayName":"Alice Smith"}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getUser(context.Background(), &sdkmcp.CallToolRequest{}, getUserArgs{UserSlug: "asmith"})
+	if err != nil {
+		t.Fatalf("getUser: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "Alice Smith") {
+		t.Error("expected display name in result")
+	}
+}
+
+func TestGetUser_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/users/ghost", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getUser(context.Background(), &sdkmcp.CallToolRequest{}, getUserArgs{UserSlug: "ghost"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":["developers"],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listGroups(context.Background(), &sdkmcp.CallToolRequest{}, listGroupsArgs{})
+	if err != nil {
+		t.Fatalf("listGroups: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "developers") {
+		t.Error("expected group in result")
+	}
+}
+
+func TestListGroups_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listGroups(context.Background(), &sdkmcp.CallToolRequest{}, listGroupsArgs{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetGroupMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/groups/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"asmith"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getGroupMembers(context.Background(), &sdkmcp.CallToolRequest{}, getGroupMembersArgs{Group: "developers"})
+	if err != nil {
+		t.Fatalf("getGroupMembers: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "asmith") {
+		t.Error("expected member in result")
+	}
+}
+
+func TestGetGroupMembers_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/groups/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getGroupMembers(context.Background(), &sdkmcp.CallToolRequest{}, getGroupMembersArgs{Group: "developers"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetUserGroups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/users/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[{"name":"developers"}],"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.getUserGroups(context.Background(), &sdkmcp.CallToolRequest{}, getUserGroupsArgs{Username: "asmith"})
+	if err != nil {
+		t.Fatalf("getUserGroups: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, "developers") {
+		t.Error("expected group in result")
+	}
+}
+
+func TestGetUserGroups_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/admin/users/more-members", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.getUserGroups(context.Background(), &sdkmcp.CallToolRequest{}, getUserGroupsArgs{Username: "asmith"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,202 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+func (s *Server) registerWebhookTools() {
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_list_webhooks",
+		Description: "List webhooks of a repository, or of a project when repository is omitted",
+	}, s.listWebhooks)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_create_webhook",
+		Description: "Create a repository or project webhook for events such as pr:opened, pr:merged or repo:refs_changed (requires admin)",
+	}, s.createWebhook)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_update_webhook",
+		Description: "Update a webhook's name, URL, events, secret or active flag; omitted fields are kept (requires admin)",
+	}, s.updateWebhook)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_delete_webhook",
+		Description: "Delete a repository or project webhook (requires admin)",
+	}, s.deleteWebhook)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "bitbucket_test_webhook",
+		Description: "Send a test delivery to a webhook or URL and return the request and response",
+	}, s.testWebhook)
+}
+
+type listWebhooksArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for project webhooks"`
+	Event         string `json:"event" jsonschema:"Only webhooks subscribed to this event, e.g. pr:opened"`
+	Start         int    `json:"start" jsonschema:"Page start (from nextPageStart)"`
+}
+
+func (s *Server) listWebhooks(ctx context.Context, req *mcp.CallToolRequest, args listWebhooksArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	hooks, err := s.client.ListWebhooks(ctx, projectKey, args.Repository, args.Event, args.Start, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(hooks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type createWebhookArgs struct {
+	WorkspaceSlug           string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository              string   `json:"repository" jsonschema:"Repository slug; omit for a project webhook"`
+	Name                    string   `json:"name" jsonschema:"required"`
+	URL                     string   `json:"url" jsonschema:"required,Endpoint that receives deliveries"`
+	Events                  []string `json:"events" jsonschema:"required,Event keys, e.g. pr:opened, pr:merged, pr:comment:added, repo:refs_changed"`
+	Secret                  string   `json:"secret" jsonschema:"Shared secret used to sign deliveries (X-Hub-Signature)"`
+	Active                  *bool    `json:"active" jsonschema:"Whether deliveries are sent (default: true)"`
+	SSLVerificationRequired *bool    `json:"sslVerificationRequired" jsonschema:"Verify the endpoint's TLS certificate (default: true)"`
+}
+
+func (s *Server) createWebhook(ctx context.Context, req *mcp.CallToolRequest, args createWebhookArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.URL == "" || len(args.Events) == 0 {
+		return nil, nil, fmt.Errorf("url and events required")
+	}
+	hook := bitbucket.Webhook{
+		Name:                    args.Name,
+		URL:                     args.URL,
+		Events:                  args.Events,
+		Active:                  args.Active == nil || *args.Active,
+		SSLVerificationRequired: args.SSLVerificationRequired == nil || *args.SSLVerificationRequired,
+	}
+	if args.Secret != "" {
+		hook.Configuration = map[string]string{"secret": args.Secret}
+	}
+	opts := s.getOpts(ctx, req)
+	created, err := s.client.CreateWebhook(ctx, projectKey, args.Repository, hook, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(created)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type updateWebhookArgs struct {
+	WorkspaceSlug           string   `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository              string   `json:"repository" jsonschema:"Repository slug; omit for a project webhook"`
+	WebhookID               int      `json:"webhookId" jsonschema:"required"`
+	Name                    string   `json:"name"`
+	URL                     string   `json:"url"`
+	Events                  []string `json:"events" jsonschema:"Replaces the subscribed events"`
+	Secret                  *string  `json:"secret" jsonschema:"New shared secret (empty string removes it)"`
+	Active                  *bool    `json:"active"`
+	SSLVerificationRequired *bool    `json:"sslVerificationRequired"`
+}
+
+func (s *Server) updateWebhook(ctx context.Context, req *mcp.CallToolRequest, args updateWebhookArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	// Bitbucket replaces the whole webhook on update, so start from the current one.
+	hook, err := s.client.GetWebhook(ctx, projectKey, args.Repository, args.WebhookID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if args.Name != "" {
+		hook.Name = args.Name
+	}
+	if args.URL != "" {
+		hook.URL = args.URL
+	}
+	if len(args.Events) > 0 {
+		hook.Events = args.Events
+	}
+	if args.Secret != nil {
+		if hook.Configuration == nil {
+			hook.Configuration = map[string]string{}
+		}
+		if *args.Secret == "" {
+			delete(hook.Configuration, "secret")
+		} else {
+			hook.Configuration["secret"] = *args.Secret
+		}
+	}
+	if args.Active != nil {
+		hook.Active = *args.Active
+	}
+	if args.SSLVerificationRequired != nil {
+		hook.SSLVerificationRequired = *args.SSLVerificationRequired
+	}
+	updated, err := s.client.UpdateWebhook(ctx, projectKey, args.Repository, args.WebhookID, *hook, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(updated)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
+
+type deleteWebhookArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for a project webhook"`
+	WebhookID     int    `json:"webhookId" jsonschema:"required"`
+}
+
+func (s *Server) deleteWebhook(ctx context.Context, req *mcp.CallToolRequest, args deleteWebhookArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	opts := s.getOpts(ctx, req)
+	if err := s.client.DeleteWebhook(ctx, projectKey, args.Repository, args.WebhookID, opts); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "deleted"}}}, nil, nil
+}
+
+type testWebhookArgs struct {
+	WorkspaceSlug string `json:"workspaceSlug" jsonschema:"Project key (default: BITBUCKET_DEFAULT_PROJECT)"`
+	Repository    string `json:"repository" jsonschema:"Repository slug; omit for a project webhook"`
+	WebhookID     int    `json:"webhookId" jsonschema:"Existing webhook to test"`
+	URL           string `json:"url" jsonschema:"URL to test instead of an existing webhook"`
+}
+
+func (s *Server) testWebhook(ctx context.Context, req *mcp.CallToolRequest, args testWebhookArgs) (*mcp.CallToolResult, any, error) {
+	projectKey := s.projectKey(args.WorkspaceSlug)
+	if projectKey == "" {
+		return nil, nil, fmt.Errorf("workspaceSlug required (or set BITBUCKET_DEFAULT_PROJECT)")
+	}
+	if args.WebhookID == 0 && args.URL == "" {
+		return nil, nil, fmt.Errorf("webhookId or url required")
+	}
+	opts := s.getOpts(ctx, req)
+	result, err := s.client.TestWebhook(ctx, projectKey, args.Repository, args.WebhookID, args.URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal response: %w", err)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil, nil
+}
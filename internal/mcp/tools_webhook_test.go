@@ -0,0 +1,274 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n8n/bitbucket-mcp/internal/bitbucket"
+)
+
+const (
+	repoWebhooksPath = "/rest/api/1.0/projects/PROJ/repos/repo/webhooks"
+	webhookJSON      = `{"id":7,"name":"ci","url":"https://ci.example.com/hook","events":["pr:opened"],"active":true,"configuration":{"secret":"old"},"sslVerificationRequired":true}`
+)
+
+func TestListWebhooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"values":[` + webhookJSON + `],"size":1,"isLastPage":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.listWebhooks(context.Background(), &sdkmcp.CallToolRequest{}, listWebhooksArgs{})
+	if err != nil {
+		t.Fatalf("listWebhooks: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"name":"ci"`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestListWebhooks_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PROJ/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.listWebhooks(context.Background(), &sdkmcp.CallToolRequest{}, listWebhooksArgs{}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.listWebhooks(context.Background(), &sdkmcp.CallToolRequest{}, listWebhooksArgs{}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	var got bitbucket.Webhook
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	_, _, err := srv.createWebhook(context.Background(), &sdkmcp.CallToolRequest{}, createWebhookArgs{
+		Repository: "repo", Name: "ci", URL: "https://ci.example.com/hook",
+		Events: []string{"pr:opened"}, Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("createWebhook: %v", err)
+	}
+	if !got.Active || !got.SSLVerificationRequired || got.Configuration["secret"] != "s3cret" {
+		t.Errorf("sent = %+v", got)
+	}
+}
+
+func TestCreateWebhook_Inactive(t *testing.T) {
+	var got bitbucket.Webhook
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	off := false
+	_, _, err := srv.createWebhook(context.Background(), &sdkmcp.CallToolRequest{}, createWebhookArgs{
+		Repository: "repo", Name: "ci", URL: "https://ci.example.com/hook",
+		Events: []string{"pr:opened"}, Active: &off, SSLVerificationRequired: &off,
+	})
+	if err != nil {
+		t.Fatalf("createWebhook: %v", err)
+	}
+	if got.Active || got.SSLVerificationRequired || got.Configuration != nil {
+		t.Errorf("sent = %+v", got)
+	}
+}
+
+func TestCreateWebhook_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(409)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	valid := createWebhookArgs{Repository: "repo", Name: "ci", URL: "https://x", Events: []string{"pr:opened"}}
+	if _, _, err := srv.createWebhook(context.Background(), &sdkmcp.CallToolRequest{}, valid); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv.createWebhook(context.Background(), &sdkmcp.CallToolRequest{}, createWebhookArgs{Name: "ci", URL: "https://x"}); err == nil {
+		t.Error("expected error for missing events")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.createWebhook(context.Background(), &sdkmcp.CallToolRequest{}, valid); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	var got bitbucket.Webhook
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	off := false
+	secret := "new"
+	_, _, err := srv.updateWebhook(context.Background(), &sdkmcp.CallToolRequest{}, updateWebhookArgs{
+		Repository: "repo", WebhookID: 7, Events: []string{"pr:merged"}, Secret: &secret, Active: &off,
+	})
+	if err != nil {
+		t.Fatalf("updateWebhook: %v", err)
+	}
+	if got.Name != "ci" || got.URL != "https://ci.example.com/hook" {
+		t.Errorf("unchanged fields not kept: %+v", got)
+	}
+	if got.Active || got.Events[0] != "pr:merged" || got.Configuration["secret"] != "new" || !got.SSLVerificationRequired {
+		t.Errorf("sent = %+v", got)
+	}
+}
+
+func TestUpdateWebhook_ClearSecret(t *testing.T) {
+	var got bitbucket.Webhook
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(webhookJSON))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":7,"name":"ci","url":"https://x","events":["pr:opened"],"active":true}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	empty := ""
+	on := true
+	_, _, err := srv.updateWebhook(context.Background(), &sdkmcp.CallToolRequest{}, updateWebhookArgs{
+		Repository: "repo", WebhookID: 7, Name: "deploy", URL: "https://deploy", Secret: &empty, SSLVerificationRequired: &on,
+	})
+	if err != nil {
+		t.Fatalf("updateWebhook: %v", err)
+	}
+	if _, ok := got.Configuration["secret"]; ok || got.Name != "deploy" || got.URL != "https://deploy" || !got.SSLVerificationRequired {
+		t.Errorf("sent = %+v", got)
+	}
+}
+
+func TestUpdateWebhook_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(400)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(webhookJSON))
+	})
+	mux.HandleFunc(repoWebhooksPath+"/8", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.updateWebhook(context.Background(), &sdkmcp.CallToolRequest{}, updateWebhookArgs{Repository: "repo", WebhookID: 7}); err == nil {
+		t.Error("expected update error")
+	}
+	if _, _, err := srv.updateWebhook(context.Background(), &sdkmcp.CallToolRequest{}, updateWebhookArgs{Repository: "repo", WebhookID: 8}); err == nil {
+		t.Error("expected get error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.updateWebhook(context.Background(), &sdkmcp.CallToolRequest{}, updateWebhookArgs{WebhookID: 7}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteWebhook(context.Background(), &sdkmcp.CallToolRequest{}, deleteWebhookArgs{Repository: "repo", WebhookID: 7}); err != nil {
+		t.Fatalf("deleteWebhook: %v", err)
+	}
+}
+
+func TestDeleteWebhook_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.deleteWebhook(context.Background(), &sdkmcp.CallToolRequest{}, deleteWebhookArgs{Repository: "repo", WebhookID: 7}); err == nil {
+		t.Error("expected API error")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.deleteWebhook(context.Background(), &sdkmcp.CallToolRequest{}, deleteWebhookArgs{WebhookID: 7}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
+
+func TestTestWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"request":{"method":"POST"},"response":{"statusCode":502}}`))
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	result, _, err := srv.testWebhook(context.Background(), &sdkmcp.CallToolRequest{}, testWebhookArgs{Repository: "repo", WebhookID: 7})
+	if err != nil {
+		t.Fatalf("testWebhook: %v", err)
+	}
+	if !strings.Contains(result.Content[0].(*sdkmcp.TextContent).Text, `"statusCode":502`) {
+		t.Errorf("result = %s", result.Content[0].(*sdkmcp.TextContent).Text)
+	}
+}
+
+func TestTestWebhook_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(repoWebhooksPath+"/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	})
+	srv, ts := bbServer(mux)
+	defer ts.Close()
+
+	if _, _, err := srv.testWebhook(context.Background(), &sdkmcp.CallToolRequest{}, testWebhookArgs{Repository: "repo", URL: "https://x"}); err == nil {
+		t.Error("expected API error")
+	}
+	if _, _, err := srv.testWebhook(context.Background(), &sdkmcp.CallToolRequest{}, testWebhookArgs{Repository: "repo"}); err == nil {
+		t.Error("expected error without webhookId or url")
+	}
+	srv.defaultProjectKey = ""
+	if _, _, err := srv.testWebhook(context.Background(), &sdkmcp.CallToolRequest{}, testWebhookArgs{WebhookID: 7}); err == nil {
+		t.Error("expected workspace error")
+	}
+}
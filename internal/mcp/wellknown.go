@@ -14,13 +14,16 @@ type ProtectedResourceMetadata struct {
 }
 
 // ProtectedResourceMetadataHandler returns an http.Handler that serves Protected Resource Metadata at
-// /.well-known/oauth-protected-resource and path-specific variants.
-func ProtectedResourceMetadataHandler(publicURL, mcpEndpoint, bitbucketURL string) http.Handler {
+// /.well-known/oauth-protected-resource and path-specific variants. authorizationServer is this
+// server's public URL when OAuth is configured (see AuthorizationServerMetadataHandler), otherwise
+// the Bitbucket base URL.
+func ProtectedResourceMetadataHandler(publicURL, mcpEndpoint, authorizationServer string) http.Handler {
 	resource := publicURL + mcpEndpoint
 	meta := ProtectedResourceMetadata{
-		Resource:               resource,
-		AuthorizationServers:   []string{bitbucketURL},
-		ScopesSupported:        []string{"REPO_READ", "REPO_WRITE", "PROJECT_READ"},
+		Resource:             resource,
+		AuthorizationServers: []string{authorizationServer},
+		// Bitbucket OAuth scopes the tools need; admin tools also need REPO_ADMIN or PROJECT_ADMIN.
+		ScopesSupported:        []string{"REPO_READ", "REPO_WRITE"},
 		BearerMethodsSupported: []string{"header"},
 	}
 	body, _ := json.Marshal(meta)